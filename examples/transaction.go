@@ -288,4 +288,32 @@ func TransactionExamples(ctx context.Context, t *testing.T, db *sqlike.Database)
 		).Decode(&user{})
 		require.Error(t, err)
 	}
+
+	// Nested transaction using savepoint
+	{
+		outer, inner := &user{ID: 4321, Name: "Outer"}, &user{ID: 4322, Name: "Inner"}
+		err = db.RunInTransaction(ctx, func(sess sqlike.SessionContext) error {
+			if _, err := sess.Table("user").InsertOne(sess, outer); err != nil {
+				return err
+			}
+			// inner failure only rollback its own work
+			err := sess.RunInTransaction(func(sess sqlike.SessionContext) error {
+				if _, err := sess.Table("user").InsertOne(sess, inner); err != nil {
+					return err
+				}
+				return errors.New("inner failed")
+			})
+			require.EqualError(t, err, "inner failed")
+			return nil
+		})
+		require.NoError(t, err)
+
+		err = db.Table("user").FindOne(ctx, actions.FindOne().Where(expr.Equal("$Key", outer.ID))).Decode(&user{})
+		require.NoError(t, err)
+		err = db.Table("user").FindOne(ctx, actions.FindOne().Where(expr.Equal("$Key", inner.ID))).Decode(&user{})
+		require.Equal(t, sql.ErrNoRows, err)
+
+		err = db.Table("user").DestroyOne(ctx, outer)
+		require.NoError(t, err)
+	}
 }
@@ -5,6 +5,7 @@ import (
 	"strings"
 	"sync"
 
+	semver "github.com/Masterminds/semver/v3"
 	"github.com/si3nloong/sqlike/reflext"
 	"github.com/si3nloong/sqlike/sql"
 	"github.com/si3nloong/sqlike/sql/codec"
@@ -12,6 +13,7 @@ import (
 	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
 	"github.com/si3nloong/sqlike/sql/util"
 	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
 	"github.com/si3nloong/sqlike/sqlike/indexes"
 	"github.com/si3nloong/sqlike/sqlike/options"
 )
@@ -33,6 +35,11 @@ type Dialect interface {
 	GetDatabases(stmt sqlstmt.Stmt)
 	CreateDatabase(stmt sqlstmt.Stmt, db string, checkExists bool)
 	DropDatabase(stmt sqlstmt.Stmt, db string, checkExists bool)
+	Savepoint(stmt sqlstmt.Stmt, name string)
+	RollbackToSavepoint(stmt sqlstmt.Stmt, name string)
+	ReleaseSavepoint(stmt sqlstmt.Stmt, name string)
+	IsRetryable(err error) bool
+	MapError(err error) error
 	HasTable(stmt sqlstmt.Stmt, db, table string)
 	HasPrimaryKey(stmt sqlstmt.Stmt, db, table string)
 	RenameTable(stmt sqlstmt.Stmt, db, oldName, newName string)
@@ -46,14 +53,22 @@ type Dialect interface {
 	GetIndexes(stmt sqlstmt.Stmt, db, table string)
 	CreateIndexes(stmt sqlstmt.Stmt, db, table string, idxs []indexes.Index, supportDesc bool)
 	DropIndexes(stmt sqlstmt.Stmt, db, table string, idxs []string)
+	UniqueIndexName(table, column string) string
+	UniqueAutoIncrement() bool
+	GetForeignKeys(stmt sqlstmt.Stmt, db, table string)
+	CreateForeignKeys(stmt sqlstmt.Stmt, db, table string, fks []foreignkeys.ForeignKey) (err error)
+	DropForeignKeys(stmt sqlstmt.Stmt, db, table string, names []string) (err error)
 	CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver.Info, fields []reflext.StructFielder) (err error)
-	AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, columns util.StringSlice, indexes util.StringSlice, unsafe bool) (err error)
+	AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, columns util.StringSlice, indexes util.StringSlice, foreignKeys util.StringSlice, unsafe bool) (drops []string, err error)
 	InsertInto(stmt sqlstmt.Stmt, db, table, pk string, mapper reflext.StructMapper, codec codec.Codecer, fields []reflext.StructFielder, values reflect.Value, opts *options.InsertOptions) (err error)
-	Select(stmt sqlstmt.Stmt, act *actions.FindActions, mode options.LockMode) (err error)
+	Select(stmt sqlstmt.Stmt, act *actions.FindActions, mode options.LockMode, of ...string) (err error)
+	ValidateLock(version *semver.Version, mode options.LockMode, of []string) error
+	ValidateInsert(info driver.Info, opt *options.InsertOptions) error
 	Update(stmt sqlstmt.Stmt, act *actions.UpdateActions) (err error)
 	Delete(stmt sqlstmt.Stmt, act *actions.DeleteActions) (err error)
 	SelectStmt(stmt sqlstmt.Stmt, query interface{}) (err error)
 	Replace(stmt sqlstmt.Stmt, db, table string, columns []string, query *sql.SelectStmt) (err error)
+	InsertFrom(stmt sqlstmt.Stmt, db, table, pk string, columns []string, query *sql.SelectStmt, opts *options.InsertOptions) (err error)
 }
 
 var (
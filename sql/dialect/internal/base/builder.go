@@ -0,0 +1,995 @@
+// Package base is the shared statement builder of the sql dialects,
+// the dialect overrides the builders which the syntax is different.
+package base
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/spatial"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/expr"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
+)
+
+var operatorMap = map[primitive.Operator]string{
+	primitive.Equal:          "=",
+	primitive.NotEqual:       "<>",
+	primitive.In:             "IN",
+	primitive.NotIn:          "NOT IN",
+	primitive.Between:        "BETWEEN",
+	primitive.NotBetween:     "NOT BETWEEN",
+	primitive.IsNull:         "IS NULL",
+	primitive.NotNull:        "IS NOT NULL",
+	primitive.GreaterThan:    ">",
+	primitive.GreaterOrEqual: ">=",
+	primitive.LesserThan:     "<",
+	primitive.LesserOrEqual:  "<=",
+	primitive.Or:             "OR",
+	primitive.And:            "AND",
+}
+
+// Dialect : the dialect specific syntax which is required by the builder
+type Dialect interface {
+	TableName(db, table string) string
+	Var(i int) string
+	Quote(n string) string
+	Wrap(n string) string
+	// WriteValue : append the encoded value as argument and write the placeholder,
+	// the value might be wrapped with function, eg. spatial value
+	WriteValue(stmt sqlstmt.Stmt, val interface{})
+	// AppendLimitedWhere : write the conditions of `UPDATE` and `DELETE` which the affected rows are sorted and limited
+	AppendLimitedWhere(stmt sqlstmt.Stmt, db, table string, conds []interface{}, sorts []interface{}, limit uint) error
+}
+
+// Builder :
+type Builder struct {
+	Dialect
+	name     string
+	Registry codec.Codecer
+	Parser   *sqlstmt.StatementBuilder
+}
+
+// New : the name is used as the prefix of error message
+func New(name string, d Dialect, rg codec.Codecer, blr *sqlstmt.StatementBuilder) *Builder {
+	if rg == nil {
+		panic("missing required registry")
+	}
+	if blr == nil {
+		panic("missing required parser")
+	}
+	return &Builder{Dialect: d, name: name, Registry: rg, Parser: blr}
+}
+
+// SetBuilders : register the builders, the dialect should register its own builders afterwards to override them
+func (b *Builder) SetBuilders(blr *sqlstmt.StatementBuilder) {
+	blr.SetBuilder(reflect.TypeOf(primitive.Invalid{}), b.BuildInvalid)
+	blr.SetBuilder(reflect.TypeOf(primitive.Func{}), b.BuildFunction)
+	blr.SetBuilder(reflect.TypeOf(primitive.Value{}), b.BuildValue)
+	blr.SetBuilder(reflect.TypeOf(primitive.As{}), b.BuildAs)
+	blr.SetBuilder(reflect.TypeOf(primitive.Nil{}), b.BuildNil)
+	blr.SetBuilder(reflect.TypeOf(primitive.Raw{}), b.BuildRaw)
+	blr.SetBuilder(reflect.TypeOf(primitive.Encoding{}), b.BuildEncoding)
+	blr.SetBuilder(reflect.TypeOf(primitive.Aggregate{}), b.BuildAggregate)
+	blr.SetBuilder(reflect.TypeOf(primitive.Window{}), b.BuildWindow)
+	blr.SetBuilder(reflect.TypeOf(primitive.Column{}), b.BuildColumn)
+	blr.SetBuilder(reflect.TypeOf(primitive.C{}), b.BuildClause)
+	blr.SetBuilder(reflect.TypeOf(primitive.L{}), b.BuildLike)
+	blr.SetBuilder(reflect.TypeOf(primitive.TypeSafe{}), b.BuildTypeSafe)
+	blr.SetBuilder(reflect.TypeOf(primitive.Operator(0)), b.BuildOperator)
+	blr.SetBuilder(reflect.TypeOf(primitive.Group{}), b.BuildGroup)
+	blr.SetBuilder(reflect.TypeOf(primitive.R{}), b.BuildRange)
+	blr.SetBuilder(reflect.TypeOf(primitive.Sort{}), b.BuildSort)
+	blr.SetBuilder(reflect.TypeOf(primitive.Join{}), b.BuildJoin)
+	blr.SetBuilder(reflect.TypeOf(primitive.KV{}), b.BuildKeyValue)
+	blr.SetBuilder(reflect.TypeOf(primitive.Math{}), b.BuildMath)
+	blr.SetBuilder(reflect.TypeOf(&primitive.Case{}), b.BuildCase)
+	blr.SetBuilder(reflect.TypeOf(&primitive.Match{}), b.BuildMatch)
+	blr.SetBuilder(reflect.TypeOf(spatial.Func{}), b.BuildSpatialFunc)
+	blr.SetBuilder(reflect.TypeOf(&sql.SelectStmt{}), b.BuildSelectStmt)
+	blr.SetBuilder(reflect.TypeOf(&sql.UpdateStmt{}), b.BuildUpdateStmt)
+	blr.SetBuilder(reflect.TypeOf(&sql.JSONTableStmt{}), b.BuildJSONTable)
+	blr.SetBuilder(reflect.TypeOf(&actions.FindActions{}), b.BuildFindActions)
+	blr.SetBuilder(reflect.TypeOf(&actions.UpdateActions{}), b.BuildUpdateActions)
+	blr.SetBuilder(reflect.TypeOf(&actions.DeleteActions{}), b.BuildDeleteActions)
+	blr.SetBuilder(reflect.String, b.BuildString)
+}
+
+// BuildInvalid :
+func (b *Builder) BuildInvalid(stmt sqlstmt.Stmt, it interface{}) error {
+	return it.(primitive.Invalid).Err
+}
+
+// BuildFunction :
+func (b *Builder) BuildFunction(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Func)
+	stmt.WriteString(x.Name)
+	stmt.WriteByte('(')
+	for i, args := range x.Args {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.Parser.BuildStatement(stmt, args); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildString :
+func (b *Builder) BuildString(stmt sqlstmt.Stmt, it interface{}) error {
+	v := reflect.ValueOf(it)
+	stmt.WriteString(b.Quote(v.String()))
+	return nil
+}
+
+// BuildLike :
+func (b *Builder) BuildLike(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.L)
+	if err := b.Parser.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+
+	stmt.WriteByte(' ')
+	if x.IsNot {
+		stmt.WriteString("NOT LIKE")
+	} else {
+		stmt.WriteString("LIKE")
+	}
+	stmt.WriteByte(' ')
+	v := reflext.ValueOf(x.Value)
+	if !v.IsValid() {
+		b.WriteArg(stmt, nil)
+		return nil
+	}
+
+	t := v.Type()
+	if builder, ok := b.Parser.LookupBuilder(t); ok {
+		if err := builder(stmt, x.Value); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	encoder, err := b.Registry.LookupEncoder(v)
+	if err != nil {
+		return err
+	}
+	vv, err := encoder(nil, v)
+	if err != nil {
+		return err
+	}
+	switch vi := vv.(type) {
+	case string:
+		vv = EscapeWildCard(vi)
+	case []byte:
+		vv = EscapeWildCard(string(vi))
+	}
+	b.WriteArg(stmt, vv)
+	return nil
+}
+
+// BuildValue :
+func (b *Builder) BuildValue(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Value)
+	v := reflext.ValueOf(x.Raw)
+	if !v.IsValid() {
+		b.WriteArg(stmt, nil)
+		return
+	}
+
+	encoder, err := b.Registry.LookupEncoder(v)
+	if err != nil {
+		return err
+	}
+	vv, err := encoder(nil, v)
+	if err != nil {
+		return err
+	}
+	b.WriteValue(stmt, vv)
+	return nil
+}
+
+// BuildColumn :
+func (b *Builder) BuildColumn(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Column)
+	if x.Table != "" {
+		stmt.WriteString(b.Quote(x.Table))
+		stmt.WriteByte('.')
+	}
+	stmt.WriteString(b.Quote(x.Name))
+	return nil
+}
+
+// BuildNil :
+func (b *Builder) BuildNil(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Nil)
+	if err := b.Parser.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+	if x.IsNot {
+		stmt.WriteString(" IS NULL")
+	} else {
+		stmt.WriteString(" IS NOT NULL")
+	}
+	return nil
+}
+
+// BuildRaw :
+func (b *Builder) BuildRaw(stmt sqlstmt.Stmt, it interface{}) error {
+	x, ok := it.(primitive.Raw)
+	if ok {
+		stmt.WriteString(x.Value)
+	}
+	return nil
+}
+
+// BuildAs :
+func (b *Builder) BuildAs(stmt sqlstmt.Stmt, it interface{}) error {
+	stmt.WriteByte('(')
+	x := it.(primitive.As)
+	if err := b.GetValue(stmt, x.Field); err != nil {
+		return err
+	}
+	stmt.WriteByte(')')
+	stmt.WriteString(" AS ")
+	stmt.WriteString(b.Quote(x.Name))
+	return nil
+}
+
+// BuildAggregate :
+func (b *Builder) BuildAggregate(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Aggregate)
+	switch x.By {
+	case primitive.Sum:
+		stmt.WriteString("COALESCE(SUM(")
+		if err := b.GetValue(stmt, x.Field); err != nil {
+			return err
+		}
+		stmt.WriteString("),0)")
+		return nil
+	case primitive.Average:
+		stmt.WriteString("AVG")
+	case primitive.Count:
+		stmt.WriteString("COUNT")
+	case primitive.Max:
+		stmt.WriteString("MAX")
+	case primitive.Min:
+		stmt.WriteString("MIN")
+	case primitive.RowNumber:
+		stmt.WriteString("ROW_NUMBER()")
+		return nil
+	case primitive.Rank:
+		stmt.WriteString("RANK()")
+		return nil
+	case primitive.DenseRank:
+		stmt.WriteString("DENSE_RANK()")
+		return nil
+	case primitive.Lag:
+		stmt.WriteString("LAG")
+	case primitive.Lead:
+		stmt.WriteString("LEAD")
+	case primitive.FirstValue:
+		stmt.WriteString("FIRST_VALUE")
+	}
+	stmt.WriteByte('(')
+	if err := b.GetValue(stmt, x.Field); err != nil {
+		return err
+	}
+	for _, arg := range x.Args {
+		stmt.WriteByte(',')
+		if err := b.GetValue(stmt, arg); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildWindow :
+func (b *Builder) BuildWindow(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Window)
+	// sum will be wrapped by `COALESCE`, so the window must be placed inside it
+	sum := x.Func.By == primitive.Sum
+	if sum {
+		stmt.WriteString("COALESCE(SUM(")
+		if err := b.GetValue(stmt, x.Func.Field); err != nil {
+			return err
+		}
+		stmt.WriteByte(')')
+	} else if err := b.BuildAggregate(stmt, x.Func); err != nil {
+		return err
+	}
+	stmt.WriteString(" OVER (")
+	if len(x.PartitionBy) > 0 {
+		stmt.WriteString("PARTITION BY ")
+		for i, f := range x.PartitionBy {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.Parser.BuildStatement(stmt, f); err != nil {
+				return err
+			}
+		}
+	}
+	if len(x.OrderBy) > 0 {
+		if len(x.PartitionBy) > 0 {
+			stmt.WriteByte(' ')
+		}
+		stmt.WriteString("ORDER BY ")
+		for i, f := range x.OrderBy {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.Parser.BuildStatement(stmt, f); err != nil {
+				return err
+			}
+		}
+	}
+	if x.Frame != nil {
+		if len(x.PartitionBy) > 0 || len(x.OrderBy) > 0 {
+			stmt.WriteByte(' ')
+		}
+		switch x.Frame.Unit {
+		case primitive.Range:
+			stmt.WriteString("RANGE ")
+		default:
+			stmt.WriteString("ROWS ")
+		}
+		stmt.WriteString("BETWEEN ")
+		if err := b.Parser.BuildStatement(stmt, x.Frame.Start); err != nil {
+			return err
+		}
+		stmt.WriteString(" AND ")
+		if err := b.Parser.BuildStatement(stmt, x.Frame.End); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	if sum {
+		stmt.WriteString(",0)")
+	}
+	return nil
+}
+
+// BuildOperator :
+func (b *Builder) BuildOperator(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Operator)
+	stmt.WriteByte(' ')
+	stmt.WriteString(operatorMap[x])
+	stmt.WriteByte(' ')
+	return nil
+}
+
+// BuildClause :
+func (b *Builder) BuildClause(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.C)
+	if err := b.Parser.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+
+	stmt.WriteString(" " + operatorMap[x.Operator] + " ")
+	switch x.Operator {
+	case primitive.IsNull, primitive.NotNull:
+		return nil
+	}
+
+	if err := b.GetValue(stmt, x.Value); err != nil {
+		return err
+	}
+	return nil
+}
+
+// BuildSort :
+func (b *Builder) BuildSort(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Sort)
+	if err := b.Parser.BuildStatement(stmt, x.Field); err != nil {
+		return err
+	}
+	if x.Order == primitive.Descending {
+		stmt.WriteByte(' ')
+		stmt.WriteString("DESC")
+	}
+	return nil
+}
+
+// BuildJoin :
+func (b *Builder) BuildJoin(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Join)
+	stmt.WriteString(x.Type.String() + " ")
+	if err := b.AppendTableRef(stmt, x.Table); err != nil {
+		return err
+	}
+	if x.Type == primitive.CrossJoin || len(x.On.Values) == 0 {
+		return nil
+	}
+	stmt.WriteString(" ON ")
+	for _, cond := range x.On.Values {
+		if err := b.Parser.BuildStatement(stmt, cond); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// BuildKeyValue :
+func (b *Builder) BuildKeyValue(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.KV)
+	stmt.WriteString(b.Quote(string(x.Field)))
+	stmt.WriteString(" = ")
+	return b.GetValue(stmt, x.Value)
+}
+
+// BuildMath :
+func (b *Builder) BuildMath(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Math)
+	stmt.WriteString(b.Quote(string(x.Field)) + " ")
+	if x.Mode == primitive.Add {
+		stmt.WriteByte('+')
+	} else {
+		stmt.WriteByte('-')
+	}
+	stmt.WriteString(" " + strconv.Itoa(x.Value))
+	return
+}
+
+// BuildCase :
+func (b *Builder) BuildCase(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*primitive.Case)
+	stmt.WriteByte('(')
+	stmt.WriteString("CASE")
+	for _, w := range x.WhenClauses {
+		stmt.WriteString(" WHEN ")
+		if err := b.Parser.BuildStatement(stmt, w[0]); err != nil {
+			return err
+		}
+		stmt.WriteString(" THEN ")
+		if err := b.GetValue(stmt, w[1]); err != nil {
+			return err
+		}
+	}
+	stmt.WriteString(" ELSE ")
+	if x.ElseClause != nil {
+		if err := b.GetValue(stmt, x.ElseClause); err != nil {
+			return err
+		}
+	}
+	stmt.WriteString(" END")
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildMatch : `MATCH ... AGAINST` is not supported by default
+func (b *Builder) BuildMatch(stmt sqlstmt.Stmt, it interface{}) error {
+	return fmt.Errorf("%s: full-text search with MATCH ... AGAINST is not supported", b.name)
+}
+
+// BuildSpatialFunc :
+func (b *Builder) BuildSpatialFunc(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(spatial.Func)
+	stmt.WriteString(x.Type.String())
+	stmt.WriteByte('(')
+	for i, arg := range x.Args {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.Parser.BuildStatement(stmt, arg); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(')')
+	return
+}
+
+// BuildGroup :
+func (b *Builder) BuildGroup(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Group)
+	for len(x.Values) > 0 {
+		if err := b.GetValue(stmt, x.Values[0]); err != nil {
+			return err
+		}
+		x.Values = x.Values[1:]
+	}
+	return
+}
+
+// BuildRange :
+func (b *Builder) BuildRange(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.R)
+	for i, val := range []interface{}{x.From, x.To} {
+		if i > 0 {
+			stmt.WriteString(" AND ")
+		}
+		v := reflext.ValueOf(val)
+		encoder, err := b.Registry.LookupEncoder(v)
+		if err != nil {
+			return err
+		}
+		arg, err := encoder(nil, v)
+		if err != nil {
+			return err
+		}
+		b.WriteArg(stmt, arg)
+	}
+	return
+}
+
+// BuildEncoding : charset introducer is not supported by default, only collation is applicable
+func (b *Builder) BuildEncoding(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Encoding)
+	err = b.Parser.BuildStatement(stmt, x.Column)
+	if err != nil {
+		return
+	}
+	stmt.WriteString(" COLLATE " + b.Quote(x.Collate))
+	return
+}
+
+// BuildTypeSafe :
+func (b *Builder) BuildTypeSafe(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	ts := it.(primitive.TypeSafe)
+	switch ts.Type {
+	case reflect.String:
+		stmt.WriteString(b.Wrap(ts.Value.(string)))
+	case reflect.Bool:
+		v := ts.Value.(bool)
+		if v {
+			stmt.WriteString("TRUE")
+		} else {
+			stmt.WriteString("FALSE")
+		}
+	case reflect.Int:
+		stmt.WriteString(strconv.FormatInt(int64(ts.Value.(int)), 10))
+	case reflect.Int8:
+		stmt.WriteString(strconv.FormatInt(int64(ts.Value.(int8)), 10))
+	case reflect.Int16:
+		stmt.WriteString(strconv.FormatInt(int64(ts.Value.(int16)), 10))
+	case reflect.Int32:
+		stmt.WriteString(strconv.FormatInt(int64(ts.Value.(int32)), 10))
+	case reflect.Int64:
+		stmt.WriteString(strconv.FormatInt(ts.Value.(int64), 10))
+	case reflect.Uint:
+		stmt.WriteString(strconv.FormatUint(uint64(ts.Value.(uint)), 10))
+	case reflect.Uint8:
+		stmt.WriteString(strconv.FormatUint(uint64(ts.Value.(uint8)), 10))
+	case reflect.Uint16:
+		stmt.WriteString(strconv.FormatUint(uint64(ts.Value.(uint16)), 10))
+	case reflect.Uint32:
+		stmt.WriteString(strconv.FormatUint(uint64(ts.Value.(uint32)), 10))
+	case reflect.Uint64:
+		stmt.WriteString(strconv.FormatUint(ts.Value.(uint64), 10))
+	case reflect.Float32:
+		stmt.WriteString(strconv.FormatFloat(float64(ts.Value.(float32)), 'e', -1, 64))
+	case reflect.Float64:
+		stmt.WriteString(strconv.FormatFloat(ts.Value.(float64), 'e', -1, 64))
+	}
+	return
+}
+
+// BuildSelectStmt :
+func (b *Builder) BuildSelectStmt(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*sql.SelectStmt)
+	if err := b.AppendWith(stmt, x.Recursive, x.CTEs); err != nil {
+		return err
+	}
+	stmt.WriteString("SELECT ")
+	if x.DistinctOn {
+		stmt.WriteString("DISTINCT ")
+	}
+	if err := b.AppendSelect(stmt, x.Projections); err != nil {
+		return err
+	}
+	stmt.WriteString(" FROM ")
+	if err := b.AppendTable(stmt, x.Tables); err != nil {
+		return err
+	}
+	for _, j := range x.Joins {
+		stmt.WriteByte(' ')
+		if err := b.Parser.BuildStatement(stmt, j); err != nil {
+			return err
+		}
+	}
+	if err := b.AppendWhere(stmt, x.Conditions.Values); err != nil {
+		return err
+	}
+	if err := b.AppendGroupBy(stmt, x.Groups); err != nil {
+		return err
+	}
+	if err := b.AppendOrderBy(stmt, x.Sorts); err != nil {
+		return err
+	}
+	b.AppendLimitNOffset(stmt, x.Max, x.Skip)
+	return nil
+}
+
+// BuildUpdateStmt :
+func (b *Builder) BuildUpdateStmt(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*sql.UpdateStmt)
+	stmt.WriteString("UPDATE " + b.TableName(x.Database, x.Table) + ` `)
+	if err := b.AppendSet(stmt, x.Values); err != nil {
+		return err
+	}
+	return b.AppendLimitedWhere(stmt, x.Database, x.Table, x.Conditions.Values, x.Sorts, x.Max)
+}
+
+// BuildJSONTable : `JSON_TABLE` is not supported by default
+func (b *Builder) BuildJSONTable(stmt sqlstmt.Stmt, it interface{}) error {
+	return fmt.Errorf("%s: JSON_TABLE is not supported", b.name)
+}
+
+// BuildFindActions :
+func (b *Builder) BuildFindActions(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*actions.FindActions)
+	x.Table = strings.TrimSpace(x.Table)
+	if x.Table == "" {
+		return fmt.Errorf("%s: empty table name", b.name)
+	}
+	stmt.WriteString("SELECT ")
+	if x.DistinctOn {
+		stmt.WriteString("DISTINCT ")
+	}
+	if err := b.AppendSelect(stmt, x.Projections); err != nil {
+		return err
+	}
+	stmt.WriteString(" FROM " + b.TableName(x.Database, x.Table))
+	if err := b.AppendJoins(stmt, x.Database, x.Joins); err != nil {
+		return err
+	}
+	if err := b.AppendWhere(stmt, x.Conditions.Values); err != nil {
+		return err
+	}
+	if err := b.AppendGroupBy(stmt, x.GroupBys); err != nil {
+		return err
+	}
+	if err := b.AppendOrderBy(stmt, x.Sorts); err != nil {
+		return err
+	}
+	b.AppendLimitNOffset(stmt, x.Count, x.Skip)
+
+	return nil
+}
+
+// BuildUpdateActions :
+func (b *Builder) BuildUpdateActions(stmt sqlstmt.Stmt, it interface{}) error {
+	x, ok := it.(*actions.UpdateActions)
+	if !ok {
+		return errors.New("data type not match")
+	}
+	stmt.WriteString("UPDATE " + b.TableName(x.Database, x.Table) + ` `)
+	if len(x.Joins) > 0 {
+		if x.Record > 0 || len(x.Sorts) > 0 {
+			return fmt.Errorf("%s: ORDER BY and LIMIT are not supported in multiple-table update", b.name)
+		}
+		// only the columns of the table can be updated, so the column must not be qualified
+		values := make([]primitive.KV, len(x.Values))
+		for i, kv := range x.Values {
+			if idx := strings.LastIndex(kv.Field, "."); idx > 0 {
+				kv.Field = kv.Field[idx+1:]
+			}
+			values[i] = kv
+		}
+		if err := b.AppendSet(stmt, values); err != nil {
+			return err
+		}
+		return b.AppendJoinedWhere(stmt, "FROM", x.Database, x.Joins, x.Conditions)
+	}
+	if err := b.AppendSet(stmt, x.Values); err != nil {
+		return err
+	}
+	return b.AppendLimitedWhere(stmt, x.Database, x.Table, x.Conditions, x.Sorts, x.Record)
+}
+
+// BuildDeleteActions : the joined tables are listed in `USING`, only the rows of the table will be deleted
+func (b *Builder) BuildDeleteActions(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(*actions.DeleteActions)
+	stmt.WriteString("DELETE FROM " + b.TableName(x.Database, x.Table))
+	if len(x.Joins) > 0 {
+		if x.Record > 0 || len(x.Sorts) > 0 {
+			return fmt.Errorf("%s: ORDER BY and LIMIT are not supported in multiple-table delete", b.name)
+		}
+		return b.AppendJoinedWhere(stmt, "USING", x.Database, x.Joins, x.Conditions)
+	}
+	return b.AppendLimitedWhere(stmt, x.Database, x.Table, x.Conditions, x.Sorts, x.Record)
+}
+
+// AppendJoinedWhere : the joined tables are listed in `FROM` (or `USING`) and the join conditions are merged into `WHERE`,
+// so only inner join is supported
+func (b *Builder) AppendJoinedWhere(stmt sqlstmt.Stmt, keyword, db string, joins []primitive.Join, conds []interface{}) error {
+	stmt.WriteString(" " + keyword + " ")
+	filters := make([]interface{}, 0, len(joins)+1)
+	for i, j := range joins {
+		if j.Type != primitive.InnerJoin {
+			return fmt.Errorf("%s: only inner join is supported in multiple-table update and delete", b.name)
+		}
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.AppendTableRef(stmt, QualifyTable(db, j.Table)); err != nil {
+			return err
+		}
+		if len(j.On.Values) > 0 {
+			filters = append(filters, j.On)
+		}
+	}
+	if len(conds) > 0 {
+		filters = append(filters, primitive.Group{Values: conds})
+	}
+	return b.AppendWhere(stmt, expr.And(filters...).Values)
+}
+
+// GetValue :
+func (b *Builder) GetValue(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	v := reflext.ValueOf(it)
+	if !v.IsValid() {
+		b.WriteArg(stmt, nil)
+		return
+	}
+
+	t := v.Type()
+	if builder, ok := b.Parser.LookupBuilder(t); ok {
+		if err := builder(stmt, it); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	encoder, err := b.Registry.LookupEncoder(v)
+	if err != nil {
+		return err
+	}
+	vv, err := encoder(nil, v)
+	if err != nil {
+		return err
+	}
+	b.WriteValue(stmt, vv)
+	return
+}
+
+// AppendWith :
+func (b *Builder) AppendWith(stmt sqlstmt.Stmt, recursive bool, ctes []primitive.CTE) error {
+	if len(ctes) == 0 {
+		return nil
+	}
+	stmt.WriteString("WITH ")
+	if recursive {
+		stmt.WriteString("RECURSIVE ")
+	}
+	for i, cte := range ctes {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString(b.Quote(cte.Name))
+		if len(cte.Columns) > 0 {
+			stmt.WriteString(" (")
+			for j, col := range cte.Columns {
+				if j > 0 {
+					stmt.WriteByte(',')
+				}
+				stmt.WriteString(b.Quote(col))
+			}
+			stmt.WriteByte(')')
+		}
+		stmt.WriteString(" AS (")
+		if err := b.Parser.BuildStatement(stmt, cte.Query); err != nil {
+			return err
+		}
+		stmt.WriteByte(')')
+	}
+	stmt.WriteByte(' ')
+	return nil
+}
+
+// AppendSelect :
+func (b *Builder) AppendSelect(stmt sqlstmt.Stmt, pjs []interface{}) error {
+	if len(pjs) > 0 {
+		length := len(pjs)
+		for i := 0; i < length; i++ {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.Parser.BuildStatement(stmt, pjs[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	stmt.WriteString("*")
+	return nil
+}
+
+// AppendTableRef : alias of table will not be wrapped with parentheses, only derived table does
+func (b *Builder) AppendTableRef(stmt sqlstmt.Stmt, table interface{}) error {
+	switch vi := table.(type) {
+	case primitive.As:
+		switch vi.Field.(type) {
+		case string, primitive.Column, *sql.JSONTableStmt:
+			if err := b.Parser.BuildStatement(stmt, vi.Field); err != nil {
+				return err
+			}
+		default:
+			stmt.WriteByte('(')
+			if err := b.Parser.BuildStatement(stmt, vi.Field); err != nil {
+				return err
+			}
+			stmt.WriteByte(')')
+		}
+		stmt.WriteString(" AS " + b.Quote(vi.Name))
+	case *sql.JSONTableStmt:
+		return fmt.Errorf("%s: JSON_TABLE must be aliased with `expr.As`", b.name)
+	case *sql.SelectStmt:
+		stmt.WriteByte('(')
+		if err := b.Parser.BuildStatement(stmt, vi); err != nil {
+			return err
+		}
+		stmt.WriteByte(')')
+	default:
+		if err := b.Parser.BuildStatement(stmt, vi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AppendTable :
+func (b *Builder) AppendTable(stmt sqlstmt.Stmt, fields []interface{}) error {
+	length := len(fields)
+	if length > 0 {
+		for i := 0; i < length; i++ {
+			if i > 0 {
+				stmt.WriteByte(' ')
+			}
+			if err := b.AppendTableRef(stmt, fields[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AppendJoins : plain table names will be qualified with the database name if it's provided
+func (b *Builder) AppendJoins(stmt sqlstmt.Stmt, db string, joins []primitive.Join) error {
+	for _, j := range joins {
+		if db != "" {
+			j.Table = QualifyTable(db, j.Table)
+		}
+		stmt.WriteByte(' ')
+		if err := b.Parser.BuildStatement(stmt, j); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AppendWhere :
+func (b *Builder) AppendWhere(stmt sqlstmt.Stmt, conds []interface{}) error {
+	length := len(conds)
+	if length > 0 {
+		stmt.WriteString(" WHERE ")
+		for i := 0; i < length; i++ {
+			if err := b.Parser.BuildStatement(stmt, conds[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AppendGroupBy :
+func (b *Builder) AppendGroupBy(stmt sqlstmt.Stmt, fields []interface{}) error {
+	length := len(fields)
+	if length > 0 {
+		stmt.WriteString(" GROUP BY ")
+		for i := 0; i < length; i++ {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.Parser.BuildStatement(stmt, fields[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// AppendOrderBy :
+func (b *Builder) AppendOrderBy(stmt sqlstmt.Stmt, sorts []interface{}) error {
+	length := len(sorts)
+	if length < 1 {
+		return nil
+	}
+	stmt.WriteString(" ORDER BY ")
+	for i := 0; i < length; i++ {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.Parser.BuildStatement(stmt, sorts[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AppendLimitNOffset :
+func (b *Builder) AppendLimitNOffset(stmt sqlstmt.Stmt, limit, offset uint) {
+	if limit > 0 {
+		stmt.WriteString(" LIMIT " + strconv.FormatUint(uint64(limit), 10))
+	}
+	if offset > 0 {
+		stmt.WriteString(" OFFSET " + strconv.FormatUint(uint64(offset), 10))
+	}
+}
+
+// AppendSet :
+func (b *Builder) AppendSet(stmt sqlstmt.Stmt, values []primitive.KV) error {
+	length := len(values)
+	if length > 0 {
+		stmt.WriteString("SET ")
+		for i := 0; i < length; i++ {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			if err := b.Parser.BuildStatement(stmt, values[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// WriteArg : append the argument and write the positional placeholder, eg. $1, $2
+func (b *Builder) WriteArg(stmt sqlstmt.Stmt, arg interface{}) {
+	stmt.AppendArgs(arg)
+	stmt.WriteString(b.Var(len(stmt.Args())))
+}
+
+// QualifyTable : the plain table name and the aliased table name (eg. `expr.As("users", "u")`) are qualified with the database name,
+// the other tables (eg. derived table) are kept as it is
+func QualifyTable(db string, table interface{}) interface{} {
+	switch vi := table.(type) {
+	case string:
+		return primitive.Column{Table: db, Name: vi}
+	case primitive.As:
+		switch f := vi.Field.(type) {
+		case string:
+			vi.Field = primitive.Column{Table: db, Name: f}
+		case primitive.Column:
+			if f.Table == "" {
+				vi.Field = primitive.Column{Table: db, Name: f.Name}
+			}
+		}
+		return vi
+	default:
+		return vi
+	}
+}
+
+// EscapeWildCard :
+func EscapeWildCard(n string) string {
+	length := len(n) - 1
+	if length < 1 {
+		return n
+	}
+	blr := new(strings.Builder)
+	for i := 0; i < length; i++ {
+		switch n[i] {
+		case '%':
+			blr.WriteString(`\%`)
+		case '_':
+			blr.WriteString(`\_`)
+		case '\\':
+			blr.WriteString(`\\`)
+		default:
+			blr.WriteByte(n[i])
+		}
+	}
+	blr.WriteByte(n[length])
+	return blr.String()
+}
@@ -0,0 +1,26 @@
+package base
+
+import (
+	"testing"
+
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQualifyTable(t *testing.T) {
+	users := primitive.Column{Table: "db", Name: "users"}
+	require.Equal(t, users, QualifyTable("db", "users"))
+	require.Equal(t, primitive.As{Field: users, Name: "u"}, QualifyTable("db", expr.As("users", "u")))
+	require.Equal(t, primitive.As{Field: users, Name: "u"}, QualifyTable("db", primitive.As{Field: "users", Name: "u"}))
+
+	// the qualified table is kept
+	other := primitive.As{Field: primitive.Column{Table: "other", Name: "users"}, Name: "u"}
+	require.Equal(t, other, QualifyTable("db", other))
+
+	// derived table is not a table name
+	query := sql.Select().From("db", "users")
+	require.Equal(t, primitive.As{Field: query, Name: "u"}, QualifyTable("db", expr.As(query, "u")))
+	require.Equal(t, query, QualifyTable("db", query))
+}
@@ -0,0 +1,88 @@
+package mysql
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	sqlerrors "github.com/si3nloong/sqlike/sqlike/errors"
+)
+
+// mysql error numbers :
+const (
+	errDupEntry         uint16 = 1062
+	errLockWaitTimeout  uint16 = 1205
+	errDeadlock         uint16 = 1213
+	errNoReferencedRow  uint16 = 1216
+	errRowIsReferenced  uint16 = 1217
+	errNoSuchTable      uint16 = 1146
+	errDataTooLong      uint16 = 1406
+	errRowIsReferenced2 uint16 = 1451
+	errNoReferencedRow2 uint16 = 1452
+)
+
+// IsRetryable : whether the transaction is able to succeed by retrying, such as deadlock and lock wait timeout
+func (ms MySQL) IsRetryable(err error) bool {
+	var e *mysql.MySQLError
+	if !errors.As(err, &e) {
+		return false
+	}
+	switch e.Number {
+	case errDeadlock, errLockWaitTimeout:
+		return true
+	}
+	return false
+}
+
+// MapError : map the mysql error number to portable error, the error is returned as it is if it's not mapped
+func (ms MySQL) MapError(err error) error {
+	var e *mysql.MySQLError
+	if !errors.As(err, &e) {
+		return err
+	}
+	var pe *sqlerrors.Error
+	if errors.As(err, &pe) {
+		return err
+	}
+	switch e.Number {
+	case errDupEntry:
+		return sqlerrors.New(sqlerrors.ErrDuplicateKey, err).WithIndex(duplicateKeyName(e.Message))
+	case errNoReferencedRow, errRowIsReferenced, errRowIsReferenced2, errNoReferencedRow2:
+		return sqlerrors.New(sqlerrors.ErrForeignKeyViolation, err).WithIndex(between(e.Message, "CONSTRAINT `", "`"))
+	case errDeadlock:
+		return sqlerrors.New(sqlerrors.ErrDeadlock, err)
+	case errLockWaitTimeout:
+		return sqlerrors.New(sqlerrors.ErrLockWaitTimeout, err)
+	case errDataTooLong:
+		return sqlerrors.New(sqlerrors.ErrDataTooLong, err)
+	case errNoSuchTable:
+		return sqlerrors.New(sqlerrors.ErrTableNotFound, err)
+	}
+	return err
+}
+
+// duplicateKeyName : the key name is prefixed with table name since mysql 8.0, eg. `Duplicate entry 'a' for key 'users.Email'`
+func duplicateKeyName(msg string) string {
+	i := strings.LastIndex(msg, "for key '")
+	if i < 0 {
+		return ""
+	}
+	name := strings.TrimSuffix(msg[i+len("for key '"):], "'")
+	if j := strings.LastIndex(name, "."); j >= 0 {
+		name = name[j+1:]
+	}
+	return name
+}
+
+func between(msg, start, end string) string {
+	i := strings.Index(msg, start)
+	if i < 0 {
+		return ""
+	}
+	msg = msg[i+len(start):]
+	j := strings.Index(msg, end)
+	if j < 0 {
+		return ""
+	}
+	return msg[:j]
+}
@@ -0,0 +1,53 @@
+package mysql
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	sqlerrors "github.com/si3nloong/sqlike/sqlike/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	ms := MySQL{}
+	require.True(t, ms.IsRetryable(&mysql.MySQLError{Number: 1213}))
+	require.True(t, ms.IsRetryable(fmt.Errorf("wrapped: %w", &mysql.MySQLError{Number: 1205})))
+	require.False(t, ms.IsRetryable(&mysql.MySQLError{Number: 1062}))
+	require.False(t, ms.IsRetryable(errors.New("Deadlock found")))
+	require.False(t, ms.IsRetryable(nil))
+}
+
+func TestMapError(t *testing.T) {
+	ms := MySQL{}
+	require.Nil(t, ms.MapError(nil))
+	other := errors.New("other")
+	require.Equal(t, other, ms.MapError(other))
+	unknown := &mysql.MySQLError{Number: 1064}
+	require.Equal(t, unknown, ms.MapError(unknown))
+
+	for _, c := range []struct {
+		err   *mysql.MySQLError
+		kind  error
+		index string
+	}{
+		{&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@b.c' for key 'Email'"}, sqlerrors.ErrDuplicateKey, "Email"},
+		{&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@b.c' for key 'users.UX_abc'"}, sqlerrors.ErrDuplicateKey, "UX_abc"},
+		{&mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`db`.`orders`, CONSTRAINT `FK_abc` FOREIGN KEY (`UserID`) REFERENCES `users` (`ID`))"}, sqlerrors.ErrForeignKeyViolation, "FK_abc"},
+		{&mysql.MySQLError{Number: 1217, Message: "Cannot delete or update a parent row: a foreign key constraint fails"}, sqlerrors.ErrForeignKeyViolation, ""},
+		{&mysql.MySQLError{Number: 1213}, sqlerrors.ErrDeadlock, ""},
+		{&mysql.MySQLError{Number: 1205}, sqlerrors.ErrLockWaitTimeout, ""},
+		{&mysql.MySQLError{Number: 1406}, sqlerrors.ErrDataTooLong, ""},
+		{&mysql.MySQLError{Number: 1146}, sqlerrors.ErrTableNotFound, ""},
+	} {
+		err := ms.MapError(fmt.Errorf("wrapped: %w", c.err))
+		require.True(t, errors.Is(err, c.kind))
+		require.True(t, errors.Is(err, c.err))
+		var e *sqlerrors.Error
+		require.True(t, errors.As(err, &e))
+		require.Equal(t, c.index, e.Index)
+		// mapping is idempotent
+		require.Equal(t, err, ms.MapError(err))
+	}
+}
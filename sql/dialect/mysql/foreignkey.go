@@ -0,0 +1,69 @@
+package mysql
+
+import (
+	"strings"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+)
+
+// GetForeignKeys : every column of the foreign key will be a row, ordered by constraint name and column position
+func (ms MySQL) GetForeignKeys(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("SELECT kcu.CONSTRAINT_NAME, kcu.COLUMN_NAME, kcu.REFERENCED_TABLE_NAME, kcu.REFERENCED_COLUMN_NAME, rc.DELETE_RULE, rc.UPDATE_RULE ")
+	stmt.WriteString("FROM INFORMATION_SCHEMA.KEY_COLUMN_USAGE AS kcu ")
+	stmt.WriteString("INNER JOIN INFORMATION_SCHEMA.REFERENTIAL_CONSTRAINTS AS rc ON rc.CONSTRAINT_SCHEMA = kcu.CONSTRAINT_SCHEMA AND rc.CONSTRAINT_NAME = kcu.CONSTRAINT_NAME ")
+	stmt.WriteString("WHERE kcu.TABLE_SCHEMA = ? AND kcu.TABLE_NAME = ? ORDER BY kcu.CONSTRAINT_NAME, kcu.ORDINAL_POSITION;")
+	stmt.AppendArgs(db, table)
+}
+
+// CreateForeignKeys :
+func (ms MySQL) CreateForeignKeys(stmt sqlstmt.Stmt, db, table string, fks []foreignkeys.ForeignKey) (err error) {
+	stmt.WriteString("ALTER TABLE " + ms.TableName(db, table) + " ")
+	for i, fk := range fks {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString("ADD ")
+		ms.buildForeignKey(stmt, db, table, fk)
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// DropForeignKeys :
+func (ms MySQL) DropForeignKeys(stmt sqlstmt.Stmt, db, table string, names []string) (err error) {
+	stmt.WriteString("ALTER TABLE " + ms.TableName(db, table) + " ")
+	for i, name := range names {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString("DROP FOREIGN KEY " + ms.Quote(name))
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+func (ms MySQL) buildForeignKey(stmt sqlstmt.Stmt, db, table string, fk foreignkeys.ForeignKey) {
+	stmt.WriteString("CONSTRAINT " + ms.Quote(fk.GetName(table)) + " FOREIGN KEY (")
+	stmt.WriteString(ms.quoteColumns(fk.Columns))
+	stmt.WriteString(") REFERENCES " + ms.TableName(db, fk.RefTable) + " (")
+	stmt.WriteString(ms.quoteColumns(fk.RefColumns))
+	stmt.WriteByte(')')
+	if fk.OnDelete != "" {
+		stmt.WriteString(" ON DELETE " + string(fk.OnDelete))
+	}
+	if fk.OnUpdate != "" {
+		stmt.WriteString(" ON UPDATE " + string(fk.OnUpdate))
+	}
+}
+
+func (ms MySQL) quoteColumns(cols []string) string {
+	blr := new(strings.Builder)
+	for i, col := range cols {
+		if i > 0 {
+			blr.WriteByte(',')
+		}
+		blr.WriteString(ms.Quote(col))
+	}
+	return blr.String()
+}
@@ -0,0 +1,72 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql/charset"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+	"github.com/stretchr/testify/require"
+)
+
+type driverInfo struct {
+	version *semver.Version
+}
+
+func (driverInfo) DriverName() string         { return "mysql" }
+func (d driverInfo) Version() *semver.Version { return d.version }
+func (driverInfo) Charset() charset.Code      { return charset.UTF8MB4 }
+func (driverInfo) Collate() string            { return "utf8mb4_unicode_ci" }
+
+type fkPost struct {
+	ID     int64 `sqlike:",primary_key"`
+	UserID int64 `sqlike:",foreign_key=users.ID,on_delete=cascade"`
+}
+
+func TestForeignKeys(t *testing.T) {
+	ms := New()
+	stmt := sqlstmt.AcquireStmt(ms)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	fk := foreignkeys.ForeignKey{Name: "fk_user", Columns: []string{"UserID"}, RefTable: "users", RefColumns: []string{"ID"}, OnDelete: foreignkeys.Cascade, OnUpdate: foreignkeys.Restrict}
+	require.NoError(t, ms.CreateForeignKeys(stmt, "db", "posts", []foreignkeys.ForeignKey{fk}))
+	require.Equal(t, "ALTER TABLE `db`.`posts` ADD CONSTRAINT `fk_user` FOREIGN KEY (`UserID`) REFERENCES `db`.`users` (`ID`) ON DELETE CASCADE ON UPDATE RESTRICT;", stmt.String())
+
+	stmt.Reset()
+	require.NoError(t, ms.DropForeignKeys(stmt, "db", "posts", []string{"fk_user", "fk_post"}))
+	require.Equal(t, "ALTER TABLE `db`.`posts` DROP FOREIGN KEY `fk_user`,DROP FOREIGN KEY `fk_post`;", stmt.String())
+
+	fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(fkPost{})).Properties()
+	fks, err := foreignkeys.FromFields(fields)
+	require.NoError(t, err)
+	name := fks[0].GetName("posts")
+
+	t.Run("CreateTable", func(ti *testing.T) {
+		stmt.Reset()
+		require.NoError(ti, ms.CreateTable(stmt, "db", "posts", "ID", driverInfo{}, fields))
+		require.Contains(ti, stmt.String(), ",PRIMARY KEY (`ID`),CONSTRAINT `"+name+"` FOREIGN KEY (`UserID`) REFERENCES `db`.`users` (`ID`) ON DELETE CASCADE) ENGINE=INNODB")
+	})
+
+	t.Run("AlterTable", func(ti *testing.T) {
+		stmt.Reset()
+		drops, err := ms.AlterTable(stmt, "db", "posts", "ID", true, driverInfo{}, fields,
+			util.StringSlice{"ID", "UserID"}, util.StringSlice{}, util.StringSlice{"FK_legacy", "custom"}, false)
+		require.NoError(ti, err)
+		require.Equal(ti, []string{"DROP FOREIGN KEY `FK_legacy`"}, drops)
+		require.Contains(ti, stmt.String(), "ALTER TABLE `db`.`posts` DROP FOREIGN KEY `FK_legacy`,MODIFY `ID`")
+		require.Contains(ti, stmt.String(), ",ADD CONSTRAINT `"+name+"` FOREIGN KEY (`UserID`)")
+		require.NotContains(ti, stmt.String(), "`custom`")
+
+		stmt.Reset()
+		drops, err = ms.AlterTable(stmt, "db", "posts", "ID", true, driverInfo{}, fields,
+			util.StringSlice{"ID", "UserID", "Legacy"}, util.StringSlice{}, util.StringSlice{name, "custom"}, true)
+		require.NoError(ti, err)
+		require.Equal(ti, []string{"DROP FOREIGN KEY `custom`", "DROP COLUMN `Legacy`"}, drops)
+		require.Contains(ti, stmt.String(), "DROP FOREIGN KEY `custom`,")
+		require.NotContains(ti, stmt.String(), "ADD CONSTRAINT")
+	})
+}
@@ -135,3 +135,14 @@ func (ms MySQL) getIndexByType(k indexes.Type) (idx string) {
 	}
 	return
 }
+
+// UniqueIndexName : name of the unique index which created by `unique_index` tag
+func (ms MySQL) UniqueIndexName(table, column string) string {
+	idx := indexes.Index{Columns: indexes.Columns(column)}
+	return idx.GetName()
+}
+
+// UniqueAutoIncrement : whether `AlterTable` creates an unique index on the `auto_increment` column
+func (ms MySQL) UniqueAutoIncrement() bool {
+	return true
+}
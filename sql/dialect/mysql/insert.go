@@ -1,16 +1,38 @@
 package mysql
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
+	semver "github.com/Masterminds/semver/v3"
 	"github.com/si3nloong/sqlike/reflext"
 	"github.com/si3nloong/sqlike/spatial"
+	"github.com/si3nloong/sqlike/sql"
 	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/driver"
 	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
 	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
 )
 
+var mysql8019 = semver.MustParse("8.0.19")
+
+// ValidateInsert : the row alias of `ON DUPLICATE KEY UPDATE` is only supported since mysql 8.0.19
+func (ms MySQL) ValidateInsert(info driver.Info, opt *options.InsertOptions) error {
+	if info == nil || opt == nil || opt.Mode != options.InsertOnDuplicate {
+		return nil
+	}
+	if opt.OnConflict == nil || opt.OnConflict.RowAlias == "" {
+		return nil
+	}
+	version := info.Version()
+	if version == nil || !version.LessThan(mysql8019) {
+		return nil
+	}
+	return fmt.Errorf("mysql: row alias of on duplicate key update is not supported on version %s, it requires %s or above", version, mysql8019)
+}
+
 // InsertInto :
 func (ms MySQL) InsertInto(stmt sqlstmt.Stmt, db, table, pk string, cache reflext.StructMapper, cdc codec.Codecer, fields []reflext.StructFielder, v reflect.Value, opt *options.InsertOptions) (err error) {
 	records := v.Len()
@@ -82,45 +104,221 @@ func (ms MySQL) InsertInto(stmt sqlstmt.Stmt, db, table, pk string, cache reflex
 		stmt.WriteByte(')')
 	}
 
-	var (
-		column string
-		name   string
-	)
 	if opt.Mode == options.InsertOnDuplicate {
-		stmt.WriteString(" ON DUPLICATE KEY UPDATE ")
-		next := false
-		for _, f := range fields {
-			name = f.Name()
-			// skip primary key on duplicate update
-			if name == pk {
-				continue
-			}
+		if err := ms.buildOnDuplicate(stmt, pk, updatableColumns(fields, pk, omitField), opt.OnConflict); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(';')
+	return
+}
 
-			// skip primary key on duplicate update
-			if _, ok := f.Tag().LookUp("primary_key"); ok {
-				continue
+// InsertFrom : `INSERT INTO ... SELECT`, row alias is not supported on duplicate
+func (ms MySQL) InsertFrom(stmt sqlstmt.Stmt, db, table, pk string, columns []string, query *sql.SelectStmt, opt *options.InsertOptions) (err error) {
+	stmt.WriteString("INSERT")
+	if opt.Mode == options.InsertIgnore {
+		stmt.WriteString(" IGNORE")
+	}
+	stmt.WriteString(" INTO " + ms.TableName(db, table) + " ")
+	if len(columns) > 0 {
+		stmt.WriteByte('(')
+		for i, col := range columns {
+			if i > 0 {
+				stmt.WriteByte(',')
 			}
-
-			if _, ok := f.Tag().LookUp("auto_increment"); ok {
+			stmt.WriteString(ms.Quote(col))
+		}
+		stmt.WriteString(") ")
+	}
+	if err := ms.parser.BuildStatement(stmt, query); err != nil {
+		return err
+	}
+	if opt.Mode == options.InsertOnDuplicate {
+		if opt.OnConflict != nil && opt.OnConflict.RowAlias != "" {
+			return errors.New("mysql: row alias is not supported in INSERT ... SELECT")
+		}
+		// the inserted columns except primary key and omitted fields will be updated by default
+		updates := make([]string, 0, len(columns))
+		for _, col := range columns {
+			if col == pk || opt.Omits.IndexOf(col) > -1 {
 				continue
 			}
+			updates = append(updates, col)
+		}
+		if err := ms.buildOnDuplicate(stmt, pk, updates, opt.OnConflict); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(';')
+	return
+}
 
-			// skip omit fields on update
-			if _, ok := omitField[name]; ok {
-				continue
-			}
+// buildOnDuplicate : the inserted value is referred by the row alias if there is, otherwise `VALUES(column)`
+func (ms MySQL) buildOnDuplicate(stmt sqlstmt.Stmt, pk string, columns []string, opt *options.OnConflictOptions) error {
+	if opt == nil {
+		opt = options.OnConflict()
+	}
+	if opt.RowAlias != "" {
+		stmt.WriteString(" AS " + ms.Quote(opt.RowAlias))
+	}
+	stmt.WriteString(" ON DUPLICATE KEY UPDATE ")
 
-			if next {
-				stmt.WriteByte(',')
-			}
+	values := make([]primitive.KV, 0, len(columns))
+	for _, name := range onConflictColumns(columns, opt) {
+		var v interface{} = primitive.Inserted{Field: name}
+		if opt.RowAlias != "" {
+			v = primitive.Column{Table: opt.RowAlias, Name: name}
+		}
+		values = append(values, primitive.KV{Field: name, Value: v})
+	}
+	values = append(values, opt.Values...)
+	if len(values) < 1 {
+		// nothing to update, fallback to primary key so the statement stays valid
+		values = append(values, primitive.KV{Field: pk, Value: primitive.Column{Name: pk}})
+	}
 
-			column = ms.Quote(name)
-			stmt.WriteString(column + "=VALUES(" + column + ")")
-			next = true
+	if len(opt.Conditions.Values) > 0 {
+		var err error
+		values, err = orderConditionalValues(values, opt)
+		if err != nil {
+			return err
 		}
 	}
-	stmt.WriteByte(';')
-	return
+
+	for i, kv := range values {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString(ms.Quote(kv.Field) + "=")
+		v := kv.Value
+		// mysql doesn't support conditional update, so every assignment keeps the current value if the conditions are not met
+		if len(opt.Conditions.Values) > 0 {
+			v = new(primitive.Case).
+				When(opt.Conditions, v).
+				Else(primitive.Column{Name: kv.Field})
+		}
+		if err := ms.buildValue(stmt, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderConditionalValues : mysql evaluates the assignments from left to right, so the condition of later assignment
+// will see the new value of the updated column. The assignment of the column which is referred by the conditions
+// is moved to the last, and it's an error if the conditions refer to more than one updated column.
+func orderConditionalValues(values []primitive.KV, opt *options.OnConflictOptions) ([]primitive.KV, error) {
+	refs := make(map[string]bool)
+	conditionColumns(opt.Conditions, opt.RowAlias, refs)
+	idx := -1
+	for i, kv := range values {
+		if !refs[kv.Field] {
+			continue
+		}
+		if idx > -1 {
+			return nil, fmt.Errorf("mysql: the conditions of on duplicate key update refer to more than one updated column, %q and %q", values[idx].Field, kv.Field)
+		}
+		idx = i
+	}
+	if idx < 0 || idx == len(values)-1 {
+		return values, nil
+	}
+	ordered := make([]primitive.KV, 0, len(values))
+	ordered = append(ordered, values[:idx]...)
+	ordered = append(ordered, values[idx+1:]...)
+	return append(ordered, values[idx]), nil
+}
+
+// conditionColumns : collect the columns of current row which are referred by the conditions,
+// the inserted values and the columns of row alias are excluded
+func conditionColumns(it interface{}, alias string, cols map[string]bool) {
+	switch vi := it.(type) {
+	case primitive.Column:
+		if alias == "" || vi.Table != alias {
+			cols[vi.Name] = true
+		}
+	case primitive.JSONColumn:
+		cols[vi.Column] = true
+	case primitive.Math:
+		cols[vi.Field] = true
+	case primitive.Group:
+		for _, v := range vi.Values {
+			conditionColumns(v, alias, cols)
+		}
+	case primitive.C:
+		conditionColumns(vi.Field, alias, cols)
+		conditionColumns(vi.Value, alias, cols)
+	case primitive.L:
+		conditionColumns(vi.Field, alias, cols)
+		conditionColumns(vi.Value, alias, cols)
+	case primitive.Nil:
+		conditionColumns(vi.Field, alias, cols)
+	case primitive.R:
+		conditionColumns(vi.From, alias, cols)
+		conditionColumns(vi.To, alias, cols)
+	case primitive.Func:
+		for _, v := range vi.Args {
+			conditionColumns(v, alias, cols)
+		}
+	case primitive.JSONFunc:
+		conditionColumns(vi.Prefix, alias, cols)
+		for _, v := range vi.Args {
+			conditionColumns(v, alias, cols)
+		}
+	case primitive.CastAs:
+		conditionColumns(vi.Value, alias, cols)
+	case primitive.As:
+		conditionColumns(vi.Field, alias, cols)
+	case *primitive.Case:
+		for _, w := range vi.WhenClauses {
+			conditionColumns(w[0], alias, cols)
+			conditionColumns(w[1], alias, cols)
+		}
+		conditionColumns(vi.ElseClause, alias, cols)
+	}
+}
+
+// updatableColumns : every column except primary key and omitted fields will be updated with the inserted value by default
+func updatableColumns(fields []reflext.StructFielder, pk string, omits map[string]bool) []string {
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		name := f.Name()
+		if name == pk {
+			continue
+		}
+		if _, ok := f.Tag().LookUp("primary_key"); ok {
+			continue
+		}
+		if _, ok := f.Tag().LookUp("auto_increment"); ok {
+			continue
+		}
+		if _, ok := omits[name]; ok {
+			continue
+		}
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// onConflictColumns : the columns which will be updated with the inserted value
+func onConflictColumns(columns []string, opt *options.OnConflictOptions) []string {
+	if len(opt.Columns) > 0 {
+		return opt.Columns
+	}
+	if len(opt.Values) > 0 {
+		return nil
+	}
+	return columns
+}
+
+// buildValue : the value which doesn't have builder will be bound as argument
+func (ms MySQL) buildValue(stmt sqlstmt.Stmt, it interface{}) error {
+	if v := reflext.ValueOf(it); v.IsValid() {
+		if _, ok := ms.parser.LookupBuilder(v.Type()); ok {
+			return ms.parser.BuildStatement(stmt, it)
+		}
+	}
+	return ms.parser.BuildStatement(stmt, primitive.Value{Raw: it})
 }
 
 func findEncoder(c codec.Codecer, sf reflext.StructFielder, v reflect.Value) (codec.ValueEncoder, error) {
@@ -0,0 +1,157 @@
+package mysql
+
+import (
+	"reflect"
+	"testing"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/expr"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type insertStruct struct {
+	ID    int64 `sqlike:",auto_increment"`
+	Email string
+	Count int
+}
+
+func TestInsertOnDuplicate(t *testing.T) {
+	var (
+		ms   = New()
+		data = []insertStruct{{ID: 1, Email: "john@example.com", Count: 1}}
+		v    = reflect.ValueOf(data)
+		cdc  = reflext.DefaultMapper.CodecByType(v.Type().Elem())
+	)
+
+	build := func(opt *options.InsertOptions) (string, []interface{}) {
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.InsertInto(stmt, "db", "users", "ID", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, opt)
+		require.NoError(t, err)
+		return stmt.String(), stmt.Args()
+	}
+
+	t.Run("Every column", func(ti *testing.T) {
+		sql, _ := build(options.Insert().SetMode(options.InsertOnDuplicate))
+		require.Equal(ti, "INSERT INTO `db`.`users` (`ID`,`Email`,`Count`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Email`=VALUES(`Email`),`Count`=VALUES(`Count`);", sql)
+	})
+
+	t.Run("Columns and values", func(ti *testing.T) {
+		sql, args := build(options.Insert().SetOnConflict(
+			options.OnConflict().
+				SetTarget("Email").
+				SetColumns("Email").
+				SetValues(
+					expr.ColumnValue("Count", expr.Increment("Count", 1)),
+					expr.ColumnValue("Status", "ACTIVE"),
+				),
+		))
+		require.Equal(ti, "INSERT INTO `db`.`users` (`ID`,`Email`,`Count`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Email`=VALUES(`Email`),`Count`=`Count` + 1,`Status`=?;", sql)
+		require.Equal(ti, []interface{}{int64(1), "john@example.com", int64(1), "ACTIVE"}, args)
+	})
+
+	t.Run("Row alias", func(ti *testing.T) {
+		sql, _ := build(options.Insert().SetOnConflict(
+			options.OnConflict().
+				SetRowAlias("new").
+				SetValues(expr.ColumnValue("Count", expr.Column("new", "Count"))),
+		))
+		require.Equal(ti, "INSERT INTO `db`.`users` (`ID`,`Email`,`Count`) VALUES (?,?,?) AS `new` ON DUPLICATE KEY UPDATE `Count`=`new`.`Count`;", sql)
+	})
+
+	t.Run("Conditional update", func(ti *testing.T) {
+		sql, _ := build(options.Insert().SetOnConflict(
+			options.OnConflict().
+				SetColumns("Count").
+				SetWhere(expr.LesserThan("Count", expr.Inserted("Count"))),
+		))
+		require.Equal(ti, "INSERT INTO `db`.`users` (`ID`,`Email`,`Count`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Count`=(CASE WHEN `Count` < VALUES(`Count`) THEN VALUES(`Count`) ELSE `Count` END);", sql)
+	})
+
+	t.Run("Conditional update with multiple columns", func(ti *testing.T) {
+		// the assignment of `Count` is moved to the last, so the condition always sees the current value
+		sql, _ := build(options.Insert().SetOnConflict(
+			options.OnConflict().
+				SetColumns("Count", "Email").
+				SetWhere(expr.LesserThan("Count", expr.Inserted("Count"))),
+		))
+		require.Equal(ti, "INSERT INTO `db`.`users` (`ID`,`Email`,`Count`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Email`=(CASE WHEN `Count` < VALUES(`Count`) THEN VALUES(`Email`) ELSE `Email` END),`Count`=(CASE WHEN `Count` < VALUES(`Count`) THEN VALUES(`Count`) ELSE `Count` END);", sql)
+
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.InsertInto(stmt, "db", "users", "ID", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, options.Insert().SetOnConflict(
+			options.OnConflict().
+				SetColumns("Count", "Email").
+				SetWhere(expr.LesserThan("Count", expr.Inserted("Count")), expr.NotEqual("Email", expr.Inserted("Email"))),
+		))
+		require.Error(ti, err)
+	})
+
+	t.Run("Case", func(ti *testing.T) {
+		opt := options.InsertOne().SetOnConflict(
+			options.OnConflict().SetValues(
+				expr.ColumnValue("Count", expr.Case().
+					When(expr.GreaterThan("Count", 10), 10).
+					Else(expr.Inserted("Count"))),
+			),
+		)
+		sql, _ := build(&opt.InsertOptions)
+		require.Equal(ti, "INSERT INTO `db`.`users` (`ID`,`Email`,`Count`) VALUES (?,?,?) ON DUPLICATE KEY UPDATE `Count`=(CASE WHEN `Count` > ? THEN ? ELSE VALUES(`Count`) END);", sql)
+	})
+}
+
+func TestValidateInsert(t *testing.T) {
+	ms := New()
+	opt := options.Insert().SetOnConflict(options.OnConflict().SetRowAlias("new"))
+	require.NoError(t, ms.ValidateInsert(driverInfo{}, opt))
+	require.NoError(t, ms.ValidateInsert(driverInfo{version: semver.MustParse("8.0.19")}, opt))
+	require.NoError(t, ms.ValidateInsert(driverInfo{version: semver.MustParse("5.7.30")}, options.Insert().SetMode(options.InsertOnDuplicate)))
+	require.EqualError(t, ms.ValidateInsert(driverInfo{version: semver.MustParse("8.0.18")}, opt), "mysql: row alias of on duplicate key update is not supported on version 8.0.18, it requires 8.0.19 or above")
+}
+
+func TestInsertFrom(t *testing.T) {
+	var (
+		ms    = New()
+		query = sql.Select("Email", "Count").
+			From("db", "archived_users").
+			Where(expr.GreaterThan("Count", 0))
+	)
+
+	build := func(opt *options.InsertOptions) (string, []interface{}) {
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.InsertFrom(stmt, "db", "users", "ID", []string{"Email", "Count"}, query, opt)
+		require.NoError(t, err)
+		return stmt.String(), stmt.Args()
+	}
+
+	t.Run("Insert", func(ti *testing.T) {
+		sql, args := build(options.Insert())
+		require.Equal(ti, "INSERT INTO `db`.`users` (`Email`,`Count`) SELECT `Email`,`Count` FROM `db`.`archived_users` WHERE `Count` > ?;", sql)
+		require.Equal(ti, []interface{}{int64(0)}, args)
+	})
+
+	t.Run("Insert ignore", func(ti *testing.T) {
+		sql, _ := build(options.Insert().SetMode(options.InsertIgnore))
+		require.Equal(ti, "INSERT IGNORE INTO `db`.`users` (`Email`,`Count`) SELECT `Email`,`Count` FROM `db`.`archived_users` WHERE `Count` > ?;", sql)
+	})
+
+	t.Run("On duplicate", func(ti *testing.T) {
+		sql, _ := build(options.Insert().SetMode(options.InsertOnDuplicate).SetOmitFields("Email"))
+		require.Equal(ti, "INSERT INTO `db`.`users` (`Email`,`Count`) SELECT `Email`,`Count` FROM `db`.`archived_users` WHERE `Count` > ? ON DUPLICATE KEY UPDATE `Count`=VALUES(`Count`);", sql)
+	})
+
+	t.Run("Row alias", func(ti *testing.T) {
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.InsertFrom(stmt, "db", "users", "ID", nil, query, options.Insert().SetOnConflict(
+			options.OnConflict().SetRowAlias("new"),
+		))
+		require.Error(ti, err)
+	})
+}
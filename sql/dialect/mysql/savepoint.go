@@ -0,0 +1,20 @@
+package mysql
+
+import (
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+)
+
+// Savepoint :
+func (ms MySQL) Savepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("SAVEPOINT " + ms.Quote(name) + ";")
+}
+
+// RollbackToSavepoint :
+func (ms MySQL) RollbackToSavepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("ROLLBACK TO SAVEPOINT " + ms.Quote(name) + ";")
+}
+
+// ReleaseSavepoint :
+func (ms MySQL) ReleaseSavepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("RELEASE SAVEPOINT " + ms.Quote(name) + ";")
+}
@@ -0,0 +1,25 @@
+package mysql
+
+import (
+	"testing"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavepoint(t *testing.T) {
+	ms := New()
+	stmt := sqlstmt.AcquireStmt(ms)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	ms.Savepoint(stmt, "sp_1")
+	require.Equal(t, "SAVEPOINT `sp_1`;", stmt.String())
+
+	stmt.Reset()
+	ms.RollbackToSavepoint(stmt, "sp_1")
+	require.Equal(t, "ROLLBACK TO SAVEPOINT `sp_1`;", stmt.String())
+
+	stmt.Reset()
+	ms.ReleaseSavepoint(stmt, "sp_1")
+	require.Equal(t, "RELEASE SAVEPOINT `sp_1`;", stmt.String())
+}
@@ -1,27 +1,82 @@
 package mysql
 
 import (
+	"fmt"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
 	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
 	"github.com/si3nloong/sqlike/sqlike/actions"
 	"github.com/si3nloong/sqlike/sqlike/options"
 )
 
+var mysql801 = semver.MustParse("8.0.1")
+
 // Select :
-func (ms *MySQL) Select(stmt sqlstmt.Stmt, f *actions.FindActions, lck options.LockMode) (err error) {
+func (ms *MySQL) Select(stmt sqlstmt.Stmt, f *actions.FindActions, lck options.LockMode, of ...string) (err error) {
 	err = ms.parser.BuildStatement(stmt, f)
 	if err != nil {
 		return
 	}
 	switch lck {
-	case options.LockForUpdate:
-		stmt.WriteString(" FOR UPDATE")
+	case options.NoLock:
 	case options.LockForRead:
-		stmt.WriteString(" LOCK IN SHARE MODE")
+		if len(of) < 1 {
+			stmt.WriteString(" LOCK IN SHARE MODE")
+			break
+		}
+		stmt.WriteString(" FOR SHARE")
+		ms.buildLockOf(stmt, of)
+	case options.LockForShareSkipLocked:
+		stmt.WriteString(" FOR SHARE")
+		ms.buildLockOf(stmt, of)
+		stmt.WriteString(" SKIP LOCKED")
+	default:
+		stmt.WriteString(" FOR UPDATE")
+		ms.buildLockOf(stmt, of)
+		switch lck {
+		case options.LockForUpdateSkipLocked:
+			stmt.WriteString(" SKIP LOCKED")
+		case options.LockForUpdateNoWait:
+			stmt.WriteString(" NOWAIT")
+		}
 	}
 	stmt.WriteByte(';')
 	return
 }
 
+// ValidateLock : `NOWAIT`, `SKIP LOCKED` and `OF` are only supported since mysql 8.0.1
+func (ms MySQL) ValidateLock(version *semver.Version, lck options.LockMode, of []string) error {
+	if version == nil || lck == options.NoLock {
+		return nil
+	}
+	switch lck {
+	case options.LockForUpdate, options.LockForRead:
+		if len(of) < 1 {
+			return nil
+		}
+	}
+	if version.LessThan(mysql801) {
+		mode := lck.String()
+		if len(of) > 0 {
+			mode += " OF"
+		}
+		return fmt.Errorf("mysql: lock %q is not supported on version %s, it requires %s or above", mode, version, mysql801)
+	}
+	return nil
+}
+
+func (ms MySQL) buildLockOf(stmt sqlstmt.Stmt, of []string) {
+	if len(of) < 1 {
+		return
+	}
+	tables := make([]string, len(of))
+	for i, table := range of {
+		tables[i] = ms.Quote(table)
+	}
+	stmt.WriteString(" OF " + strings.Join(tables, ","))
+}
+
 // SelectStmt :
 func (ms *MySQL) SelectStmt(stmt sqlstmt.Stmt, query interface{}) (err error) {
 	err = ms.parser.BuildStatement(stmt, query)
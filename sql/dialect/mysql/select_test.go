@@ -1,13 +1,16 @@
 package mysql
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 
+	semver "github.com/Masterminds/semver/v3"
 	"github.com/si3nloong/sqlike/sql"
 	"github.com/si3nloong/sqlike/sql/expr"
 	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
 	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/options"
 	"github.com/stretchr/testify/require"
 )
 
@@ -91,4 +94,367 @@ func TestSelect(t *testing.T) {
 		err := x.parser.BuildStatement(stmt2, stmt)
 		require.NoError(t, err)
 	}
+
+	// Select with joins
+	{
+		stmt := sqlstmt.AcquireStmt(MySQL{})
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = New().Select(
+			stmt,
+			actions.Find().
+				Select(
+					expr.Qualified("User", "ID"),
+					expr.Qualified("o", "Amount"),
+				).
+				From("db", "User").
+				InnerJoin(expr.As("Order", "o")).
+				On(
+					expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID")),
+					expr.GreaterThan(expr.Column("o", "Amount"), 10),
+				).
+				LeftJoin("Address").
+				On(expr.Equal(expr.Column("Address", "UserID"), expr.Column("User", "ID"))).
+				CrossJoin("Country").
+				Where(expr.Equal(expr.Column("User", "Status"), "ACTIVE")).(*actions.FindActions), 0,
+		)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT (`User`.`ID`) AS `User.ID`,(`o`.`Amount`) AS `o.Amount` FROM `db`.`User` INNER JOIN `db`.`Order` AS `o` ON (`o`.`UserID` = `User`.`ID` AND `o`.`Amount` > ?) LEFT JOIN `db`.`Address` ON `Address`.`UserID` = `User`.`ID` CROSS JOIN `db`.`Country` WHERE `User`.`Status` = ?;", stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(10), "ACTIVE"}, stmt.Args())
+	}
+
+	{
+		x := New()
+		stmt := sqlstmt.NewStatement(x)
+		err = x.parser.BuildStatement(stmt, sql.Select().
+			From("db", "User").
+			RightJoin(expr.As(sql.Select().From("db", "Order"), "o")).
+			On(expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID"))),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM `db`.`User` RIGHT JOIN (SELECT * FROM `db`.`Order`) AS `o` ON `o`.`UserID` = `User`.`ID`", stmt.String())
+	}
+
+	// on conditions without joined table
+	{
+		stmt := sqlstmt.AcquireStmt(MySQL{})
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = New().Select(stmt, actions.Find().From("User").On(expr.Equal("A", 1)).(*actions.FindActions), 0)
+		require.EqualError(t, err, "actions: missing join table for on conditions")
+
+		x := New()
+		stmt.Reset()
+		err = x.parser.BuildStatement(stmt, sql.Select().From("db", "User").On(expr.Equal("A", 1)))
+		require.EqualError(t, err, "sql: missing join table for on conditions")
+
+		// the joins of select statement accept any buildable value
+		q := sql.Select().From("db", "User")
+		q.Joins = append(q.Joins, expr.Raw("NATURAL JOIN `db`.`Profile`"))
+		stmt.Reset()
+		require.NoError(t, x.parser.BuildStatement(stmt, q))
+		require.Equal(t, "SELECT * FROM `db`.`User` NATURAL JOIN `db`.`Profile`", stmt.String())
+	}
+
+	// Select with common table expressions
+	{
+		x := New()
+		stmt := sqlstmt.NewStatement(x)
+		err = x.SelectStmt(stmt, sql.WithRecursive("tree",
+			expr.UnionAll(
+				sql.Select("ID", "ParentID", expr.As(expr.Raw("1"), "Depth")).
+					From("db", "Category").
+					Where(expr.IsNull("ParentID")),
+				sql.Select(
+					expr.Column("c", "ID"),
+					expr.Column("c", "ParentID"),
+					expr.Raw("`tree`.`Depth` + 1"),
+				).
+					From(expr.As(expr.Column("db", "Category"), "c")).
+					InnerJoin("tree").
+					On(expr.Equal(expr.Column("tree", "ID"), expr.Column("c", "ParentID"))),
+			),
+			"ID", "ParentID", "Depth",
+		).
+			With("top", sql.Select("ID").From("tree").Where(expr.Equal("Depth", 1))).
+			Select("ID", "Depth").
+			From("tree").
+			Where(expr.NotIn("ID", sql.Select("ID").From("top"))),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "WITH RECURSIVE `tree` (`ID`,`ParentID`,`Depth`) AS ((SELECT `ID`,`ParentID`,(1) AS `Depth` FROM `db`.`Category` WHERE `ParentID` IS NULL) UNION ALL (SELECT `c`.`ID`,`c`.`ParentID`,`tree`.`Depth` + 1 FROM `db`.`Category` AS `c` INNER JOIN `tree` ON `tree`.`ID` = `c`.`ParentID`)),`top` AS (SELECT `ID` FROM `tree` WHERE `Depth` = ?) SELECT `ID`,`Depth` FROM `tree` WHERE `ID` NOT IN (SELECT `ID` FROM `top`);", stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(1)}, stmt.Args())
+	}
+
+	// the empty name of common table expression
+	{
+		x := New()
+		stmt := sqlstmt.NewStatement(x)
+		err = x.SelectStmt(stmt, sql.With("", sql.Select().From("db", "User")).From("tree"))
+		require.EqualError(t, err, "sql: empty common table expression name")
+	}
+
+	// the derived table is wrapped with parentheses
+	{
+		x := New()
+		stmt := sqlstmt.NewStatement(x)
+		err = x.SelectStmt(stmt, sql.Select("ID").From(sql.Select("ID").From("db", "User").Where(expr.Equal("Status", "ACTIVE"))))
+		require.NoError(t, err)
+		require.Equal(t, "SELECT `ID` FROM (SELECT `ID` FROM `db`.`User` WHERE `Status` = ?);", stmt.String())
+	}
+
+	// Select with window functions
+	{
+		x := New()
+		stmt := sqlstmt.NewStatement(x)
+		err = x.SelectStmt(stmt, sql.Select(
+			"ID",
+			expr.As(expr.Sum("Amount").Over(
+				[]interface{}{"UserID"},
+				[]interface{}{expr.Asc("CreatedAt")},
+				expr.Rows(expr.UnboundedPreceding(), expr.CurrentRow()),
+			), "RunningTotal"),
+			expr.As(expr.Lag("Amount", 1, 0).Over(nil, []interface{}{"CreatedAt"}, nil), "Previous"),
+			expr.As(expr.FirstValue("Amount").Over([]interface{}{"UserID"}, nil, expr.Range(expr.Preceding(2), expr.Following(2))), "First"),
+		).From("db", "Order"))
+		require.NoError(t, err)
+		require.Equal(t, "SELECT `ID`,(COALESCE(SUM(`Amount`) OVER (PARTITION BY `UserID` ORDER BY `CreatedAt` ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW),0)) AS `RunningTotal`,(LAG(`Amount`,1,?) OVER (ORDER BY `CreatedAt`)) AS `Previous`,(FIRST_VALUE(`Amount`) OVER (PARTITION BY `UserID` RANGE BETWEEN 2 PRECEDING AND 2 FOLLOWING)) AS `First` FROM `db`.`Order`;", stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(0)}, stmt.Args())
+	}
+
+	// Top-N per group
+	{
+		x := New()
+		stmt := sqlstmt.NewStatement(x)
+		err = x.SelectStmt(stmt, sql.Select("ID", "UserID").
+			From(expr.As(sql.Select(
+				"ID", "UserID",
+				expr.As(expr.RowNumber().Over([]interface{}{"UserID"}, []interface{}{expr.Desc("Amount")}, nil), "Rank"),
+			).From("db", "Order"), "t")).
+			Where(expr.LesserOrEqual("Rank", 3)),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT `ID`,`UserID` FROM (SELECT `ID`,`UserID`,(ROW_NUMBER() OVER (PARTITION BY `UserID` ORDER BY `Amount` DESC)) AS `Rank` FROM `db`.`Order`) AS `t` WHERE `Rank` <= ?;", stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(3)}, stmt.Args())
+	}
+}
+
+func TestSelectLock(t *testing.T) {
+	ms := New()
+	act := actions.Find().From("db", "Job").Where(expr.Equal("Status", "PENDING")).Limit(10).(*actions.FindActions)
+
+	for lck, suffix := range map[options.LockMode]string{
+		options.NoLock:                  "",
+		options.LockForUpdate:           " FOR UPDATE",
+		options.LockForRead:             " LOCK IN SHARE MODE",
+		options.LockForUpdateSkipLocked: " FOR UPDATE SKIP LOCKED",
+		options.LockForUpdateNoWait:     " FOR UPDATE NOWAIT",
+		options.LockForShareSkipLocked:  " FOR SHARE SKIP LOCKED",
+	} {
+		stmt := sqlstmt.NewStatement(ms)
+		require.NoError(t, ms.Select(stmt, act, lck))
+		require.Equal(t, "SELECT * FROM `db`.`Job` WHERE `Status` = ? LIMIT 10"+suffix+";", stmt.String())
+	}
+
+	stmt := sqlstmt.NewStatement(ms)
+	require.NoError(t, ms.Select(stmt, act, options.LockForRead, "Job", "j"))
+	require.Equal(t, "SELECT * FROM `db`.`Job` WHERE `Status` = ? LIMIT 10 FOR SHARE OF `Job`,`j`;", stmt.String())
+
+	stmt = sqlstmt.NewStatement(ms)
+	require.NoError(t, ms.Select(stmt, act, options.LockForUpdateSkipLocked, "Job"))
+	require.Equal(t, "SELECT * FROM `db`.`Job` WHERE `Status` = ? LIMIT 10 FOR UPDATE OF `Job` SKIP LOCKED;", stmt.String())
+
+	mysql57 := semver.MustParse("5.7.30")
+	require.NoError(t, ms.ValidateLock(mysql57, options.LockForUpdate, nil))
+	require.NoError(t, ms.ValidateLock(mysql57, options.LockForRead, nil))
+	require.EqualError(t, ms.ValidateLock(mysql57, options.LockForUpdateSkipLocked, nil), `mysql: lock "FOR UPDATE SKIP LOCKED" is not supported on version 5.7.30, it requires 8.0.1 or above`)
+	require.EqualError(t, ms.ValidateLock(mysql57, options.LockForUpdate, []string{"Job"}), `mysql: lock "FOR UPDATE OF" is not supported on version 5.7.30, it requires 8.0.1 or above`)
+	require.NoError(t, ms.ValidateLock(semver.MustParse("8.0.21"), options.LockForUpdateNoWait, []string{"Job"}))
+	require.NoError(t, ms.ValidateLock(nil, options.LockForShareSkipLocked, nil))
+}
+
+func TestUpdateAndDeleteWithJoin(t *testing.T) {
+	ms := New()
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		act := actions.Update().
+			InnerJoin(expr.As("Order", "o")).
+			On(expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID"))).
+			Set(expr.ColumnValue("User.Status", "VIP")).
+			Where(expr.GreaterThan(expr.Column("o", "Amount"), 100)).(*actions.UpdateActions)
+		act.Database = "db"
+		act.Table = "User"
+		err := ms.Update(stmt, act)
+		require.NoError(t, err)
+		require.Equal(t, "UPDATE `db`.`User` INNER JOIN `db`.`Order` AS `o` ON `o`.`UserID` = `User`.`ID` SET `User`.`Status` = ? WHERE `o`.`Amount` > ?;", stmt.String())
+		require.Equal(t, []interface{}{"VIP", int64(100)}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		act := actions.Update().
+			InnerJoin("Order").
+			On(expr.Equal(expr.Column("Order", "UserID"), expr.Column("User", "ID"))).
+			Set(expr.ColumnValue("Status", "VIP")).
+			Limit(1).(*actions.UpdateActions)
+		act.Database = "db"
+		act.Table = "User"
+		err := ms.Update(stmt, act)
+		require.Error(t, err)
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		act := actions.Delete().
+			InnerJoin(expr.As("Order", "o")).
+			On(expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID"))).
+			Where(expr.Equal(expr.Column("o", "Status"), "FRAUD")).(*actions.DeleteActions)
+		act.Database = "db"
+		act.Table = "User"
+		err := ms.Delete(stmt, act)
+		require.NoError(t, err)
+		require.Equal(t, "DELETE `db`.`User` FROM `db`.`User` INNER JOIN `db`.`Order` AS `o` ON `o`.`UserID` = `User`.`ID` WHERE `o`.`Status` = ?;", stmt.String())
+		require.Equal(t, []interface{}{"FRAUD"}, stmt.Args())
+	}
+}
+
+func TestSelectMatch(t *testing.T) {
+	ms := New()
+	score := expr.Match("Title", "Body").Against("database", expr.NaturalLanguageMode)
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.Select(
+			stmt,
+			actions.Find().
+				Select("ID", expr.As(score, "Score")).
+				From("db", "Post").
+				Where(expr.Match("Title", "Body").Against("+mysql -oracle", expr.BooleanMode)).
+				OrderBy(expr.Desc(score)).(*actions.FindActions), 0,
+		)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT `ID`,(MATCH(`Title`,`Body`) AGAINST(? IN NATURAL LANGUAGE MODE)) AS `Score` FROM `db`.`Post` WHERE MATCH(`Title`,`Body`) AGAINST(? IN BOOLEAN MODE) ORDER BY MATCH(`Title`,`Body`) AGAINST(? IN NATURAL LANGUAGE MODE) DESC;", stmt.String())
+		require.Equal(t, []interface{}{"database", "+mysql -oracle", "database"}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.Select(
+			stmt,
+			actions.Find().
+				From("db", "Post").
+				Where(expr.Match(expr.Column("p", "Title")).Against("database", expr.QueryExpansionMode)).(*actions.FindActions), 0,
+		)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM `db`.`Post` WHERE MATCH(`p`.`Title`) AGAINST(? WITH QUERY EXPANSION);", stmt.String())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.Select(stmt, actions.Find().From("db", "Post").Where(expr.Match().Against("database", expr.BooleanMode)).(*actions.FindActions), 0)
+		require.Error(t, err)
+	}
+}
+
+func TestSelectJSONPredicates(t *testing.T) {
+	ms := New()
+	stmt := sqlstmt.AcquireStmt(ms)
+	defer sqlstmt.ReleaseStmt(stmt)
+	err := ms.Select(
+		stmt,
+		actions.Find().
+			From("db", "User").
+			Where(
+				expr.MemberOf(int64(10), expr.JSONColumn("Info", "ids")),
+				expr.JSONContains(expr.JSONColumn("Info", "tags"), []string{"a", "b"}),
+				expr.JSONOverlaps("Roles", []string{"admin"}),
+				expr.GreaterThan(expr.JSONLength("Roles"), 1),
+				expr.Equal(expr.JSONContainsPath("Info", "one", "$.ids", "$.tags"), 1),
+				expr.Equal(expr.JSONSearch("Roles", "one", "adm%"), "$[0]"),
+			).(*actions.FindActions), 0,
+	)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT * FROM `db`.`User` WHERE (? MEMBER OF(`Info`->'$.ids') AND JSON_CONTAINS(`Info`->'$.tags',CAST(? AS JSON)) AND JSON_OVERLAPS(`Roles`,CAST(? AS JSON)) AND JSON_LENGTH(`Roles`) > ? AND JSON_CONTAINS_PATH(`Info`,?,?,?) = ? AND JSON_SEARCH(`Roles`,?,?) = ?);", stmt.String())
+	require.Equal(t, []interface{}{int64(10), `["a","b"]`, `["admin"]`, int64(1), "one", "$.ids", "$.tags", int64(1), "one", "adm%", "$[0]"}, stmt.Args())
+
+	stmt.Reset()
+	err = ms.Select(
+		stmt,
+		actions.Find().
+			From("db", "User").
+			Where(expr.JSONContains("Roles", make(chan int))).(*actions.FindActions), 0,
+	)
+	require.Error(t, err)
+
+	stmt.Reset()
+	err = ms.Select(
+		stmt,
+		actions.Find().
+			From("db", "User").
+			Where(expr.GreaterThan(expr.JSONLength("Roles", "$.a", "$.b"), 1)).(*actions.FindActions), 0,
+	)
+	require.EqualError(t, err, "expr: JSON_LENGTH only accepts one path")
+}
+
+func TestSelectJSONTable(t *testing.T) {
+	ms := New()
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.parser.BuildStatement(stmt, sql.Select(
+			expr.Column("o", "ID"),
+			expr.Column("i", "SKU"),
+			expr.Column("i", "Qty"),
+		).
+			From("db", "Order", "o").
+			CrossJoin(expr.As(sql.JSONTable(
+				expr.Column("o", "Items"),
+				"$[*]",
+				sql.OrdinalityColumn("Seq"),
+				sql.PathColumn("SKU", "VARCHAR(20)", "$.sku"),
+				sql.PathColumn("Qty", "INT", "$.qty").DefaultOnEmpty("1").DefaultOnError("0"),
+				sql.ExistsPathColumn("HasDiscount", "TINYINT", "$.discount"),
+				sql.NestedPath("$.tags[*]", sql.PathColumn("Tag", "VARCHAR(50)", "$")),
+			), "i")).
+			Where(expr.GreaterThan(expr.Column("i", "Qty"), 1)),
+		)
+		require.NoError(t, err)
+		require.Equal(t, "SELECT `o`.`ID`,`i`.`SKU`,`i`.`Qty` FROM `db`.`Order` `o` CROSS JOIN JSON_TABLE(`o`.`Items`,'$[*]' COLUMNS(`Seq` FOR ORDINALITY,`SKU` VARCHAR(20) PATH '$.sku',`Qty` INT PATH '$.qty' DEFAULT '1' ON EMPTY DEFAULT '0' ON ERROR,`HasDiscount` TINYINT EXISTS PATH '$.discount',NESTED PATH '$.tags[*]' COLUMNS(`Tag` VARCHAR(50) PATH '$'))) AS `i` WHERE `i`.`Qty` > ?", stmt.String())
+		require.Equal(t, []interface{}{int64(1)}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.parser.BuildStatement(stmt, sql.Select().From(
+			expr.As(sql.JSONTable(json.RawMessage(`[{"a":1}]`), "$[*]", sql.PathColumn("A", "INT", "$.a")), "t"),
+		))
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM JSON_TABLE(?,'$[*]' COLUMNS(`A` INT PATH '$.a')) AS `t`", stmt.String())
+		require.Equal(t, []interface{}{json.RawMessage(`[{"a":1}]`)}, stmt.Args())
+	}
+
+	{
+		// the single quote of path and default value is escaped
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.parser.BuildStatement(stmt, sql.Select().From(
+			expr.As(sql.JSONTable("Doc", "$", sql.PathColumn("Name", "VARCHAR(20)", `$."it's"`).DefaultOnEmpty(`"o'neil"`)), "t"),
+		))
+		require.NoError(t, err)
+		require.Equal(t, "SELECT * FROM JSON_TABLE(`Doc`,'$' COLUMNS(`Name` VARCHAR(20) PATH '$.\"it''s\"' DEFAULT '\"o''neil\"' ON EMPTY)) AS `t`", stmt.String())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(ms)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := ms.parser.BuildStatement(stmt, sql.Select().From(sql.JSONTable("Items", "$[*]", sql.OrdinalityColumn("Seq"))))
+		require.Error(t, err)
+	}
 }
@@ -9,6 +9,7 @@ import (
 	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
 	"github.com/si3nloong/sqlike/sql/util"
 	"github.com/si3nloong/sqlike/sqlike/columns"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
 	"github.com/si3nloong/sqlike/sqlike/indexes"
 )
 
@@ -60,6 +61,11 @@ func (ms MySQL) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver
 		stored  bool
 	)
 
+	fks, err := foreignkeys.FromFields(fields)
+	if err != nil {
+		return err
+	}
+
 	stmt.WriteString("CREATE TABLE " + ms.TableName(db, table) + " ")
 	stmt.WriteByte('(')
 
@@ -96,7 +102,7 @@ func (ms MySQL) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver
 			if len(v) > 60 {
 				panic("maximum length of comment is 60 characters")
 			}
-			stmt.WriteString(" COMMENT '" + v + "'")
+			stmt.WriteString(" COMMENT " + ms.Wrap(v))
 		}
 
 		// check generated columns
@@ -147,6 +153,10 @@ func (ms MySQL) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver
 		stmt.WriteByte(',')
 		stmt.WriteString("PRIMARY KEY (" + ms.Quote(pkk.Name()) + ")")
 	}
+	for _, fk := range fks {
+		stmt.WriteByte(',')
+		ms.buildForeignKey(stmt, db, table, fk)
+	}
 	stmt.WriteByte(')')
 	stmt.WriteString(" ENGINE=INNODB")
 	code := string(info.Charset())
@@ -163,8 +173,8 @@ func (ms MySQL) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver
 	return
 }
 
-// AlterTable :
-func (ms *MySQL) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, cols util.StringSlice, idxs util.StringSlice, unsafe bool) (err error) {
+// AlterTable : it returns the clauses which drop data, eg. `DROP COLUMN` or `DROP FOREIGN KEY`
+func (ms *MySQL) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, cols util.StringSlice, idxs util.StringSlice, fkNames util.StringSlice, unsafe bool) (drops []string, err error) {
 	var (
 		col     columns.Column
 		pkk     reflext.StructFielder
@@ -174,8 +184,20 @@ func (ms *MySQL) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool,
 		stored  bool
 	)
 
+	fks, err := foreignkeys.FromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	adds, dropFks := foreignkeys.Diff(table, fks, fkNames, unsafe)
+
 	suffix := "FIRST"
 	stmt.WriteString("ALTER TABLE " + ms.TableName(db, table) + " ")
+	// drop the foreign keys first, so the referencing columns are able to be altered
+	for _, name := range dropFks {
+		clause := "DROP FOREIGN KEY " + ms.Quote(name)
+		stmt.WriteString(clause + ",")
+		drops = append(drops, clause)
+	}
 
 	for i, sf := range fields {
 		if i > 0 {
@@ -220,7 +242,7 @@ func (ms *MySQL) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool,
 			if len(v) > 60 {
 				panic("maximum length of comment is 60 characters")
 			}
-			stmt.WriteString(" COMMENT '" + v + "'")
+			stmt.WriteString(" COMMENT " + ms.Wrap(v))
 		}
 
 		stmt.WriteString(" " + suffix)
@@ -286,11 +308,17 @@ func (ms *MySQL) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool,
 		stmt.WriteString("ADD PRIMARY KEY (" + ms.Quote(pkk.Name()) + ")")
 	}
 
+	for _, fk := range adds {
+		stmt.WriteString(",ADD ")
+		ms.buildForeignKey(stmt, db, table, fk)
+	}
+
 	if unsafe {
 		for _, col := range cols {
+			clause := "DROP COLUMN " + ms.Quote(col)
 			stmt.WriteByte(',')
-			stmt.WriteString("DROP COLUMN ")
-			stmt.WriteString(ms.Quote(col))
+			stmt.WriteString(clause)
+			drops = append(drops, clause)
 		}
 	}
 
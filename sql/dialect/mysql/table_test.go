@@ -1,8 +1,10 @@
 package mysql
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/si3nloong/sqlike/reflext"
 	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
 	"github.com/stretchr/testify/require"
 )
@@ -66,3 +68,18 @@ func TestHasTable(t *testing.T) {
 	require.ElementsMatch(t, []interface{}{"db", "table"}, stmt.Args())
 
 }
+
+type commentedTable struct {
+	ID   int64  `sqlike:",primary_key"`
+	Name string `sqlike:",comment=it's a\\' OR 1=1"`
+}
+
+func TestCreateTableComment(t *testing.T) {
+	ms := New()
+	stmt := sqlstmt.AcquireStmt(ms)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(commentedTable{})).Properties()
+	require.NoError(t, ms.CreateTable(stmt, "db", "table", "ID", driverInfo{}, fields))
+	require.Contains(t, stmt.String(), ` COMMENT 'it''s a\\'' OR 1=1',`)
+}
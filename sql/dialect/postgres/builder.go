@@ -0,0 +1,204 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/dialect/internal/base"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
+)
+
+// postgresBuilder : only the builders which the syntax is different are overridden, the rest are shared in `base.Builder`
+type postgresBuilder struct {
+	*base.Builder
+	sqlutil.PostgresUtil
+}
+
+func (b postgresBuilder) SetRegistryAndBuilders(rg codec.Codecer, blr *sqlstmt.StatementBuilder) {
+	b.Builder = base.New("postgres", &b, rg, blr)
+	b.SetBuilders(blr)
+	blr.SetBuilder(reflect.TypeOf(primitive.CastAs{}), b.BuildCastAs)
+	blr.SetBuilder(reflect.TypeOf(primitive.JSONFunc{}), b.BuildJSONFunction)
+	blr.SetBuilder(reflect.TypeOf(primitive.Field{}), b.BuildField)
+	blr.SetBuilder(reflect.TypeOf(primitive.JSONColumn{}), b.BuildJSONColumn)
+	blr.SetBuilder(reflect.TypeOf(primitive.Inserted{}), b.BuildInserted)
+}
+
+// WriteValue :
+func (b *postgresBuilder) WriteValue(stmt sqlstmt.Stmt, val interface{}) {
+	convertSpatial(stmt, b.PostgresUtil, val)
+}
+
+// BuildCastAs :
+func (b *postgresBuilder) BuildCastAs(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.CastAs)
+	stmt.WriteString("CAST(")
+	if err := b.Parser.BuildStatement(stmt, x.Value); err != nil {
+		return err
+	}
+	stmt.WriteString(" AS ")
+	switch x.DataType {
+	case primitive.JSON:
+		stmt.WriteString("JSONB")
+	case primitive.Varchar:
+		stmt.WriteString("VARCHAR")
+	case primitive.Char:
+		stmt.WriteString("CHAR")
+	case primitive.Date:
+		stmt.WriteString("DATE")
+	default:
+		return errors.New("postgres: unsupported cast as data type")
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildJSONFunction : postgres doesn't have the `JSON_*` functions, so we translate them into `jsonb` operators and functions.
+func (b *postgresBuilder) BuildJSONFunction(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.JSONFunc)
+	switch x.Type {
+	case primitive.JSON_CONTAINS:
+		if len(x.Args) != 2 {
+			return errors.New("postgres: JSON_CONTAINS with path is not supported")
+		}
+		stmt.WriteByte('(')
+		if err := b.buildJSONB(stmt, x.Args[0]); err != nil {
+			return err
+		}
+		stmt.WriteString(" @> ")
+		if err := b.buildJSONB(stmt, x.Args[1]); err != nil {
+			return err
+		}
+		stmt.WriteByte(')')
+	case primitive.MEMBER_OF:
+		stmt.WriteByte('(')
+		if err := b.buildJSONB(stmt, x.Args[0]); err != nil {
+			return err
+		}
+		stmt.WriteString(" @> TO_JSONB(")
+		if err := b.GetValue(stmt, x.Prefix); err != nil {
+			return err
+		}
+		stmt.WriteString("))")
+	case primitive.JSON_TYPE:
+		stmt.WriteString("UPPER(JSONB_TYPEOF(")
+		if err := b.buildJSONB(stmt, x.Args[0]); err != nil {
+			return err
+		}
+		stmt.WriteString("))")
+	case primitive.JSON_QUOTE:
+		stmt.WriteString("TO_JSONB(")
+		if err := b.Parser.BuildStatement(stmt, x.Args[0]); err != nil {
+			return err
+		}
+		stmt.WriteString("::TEXT)")
+	case primitive.JSON_UNQUOTE:
+		stmt.WriteByte('(')
+		if err := b.buildJSONB(stmt, x.Args[0]); err != nil {
+			return err
+		}
+		stmt.WriteString(" #>> '{}')")
+	default:
+		return fmt.Errorf("postgres: unsupported json function %s", x.Type)
+	}
+	return nil
+}
+
+// BuildField : postgres doesn't support `FIELD`, we use `ARRAY_POSITION` instead
+func (b *postgresBuilder) BuildField(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Field)
+	stmt.WriteString("ARRAY_POSITION(ARRAY[")
+	for i, v := range x.Values {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.GetValue(stmt, v); err != nil {
+			return err
+		}
+	}
+	stmt.WriteString("],")
+	stmt.WriteString(b.Quote(x.Name))
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildJSONColumn :
+func (b *postgresBuilder) BuildJSONColumn(stmt sqlstmt.Stmt, it interface{}) error {
+	/*
+		Expected columns
+		Column : Address
+		Nested : [ State, City ]
+		UnquoteResult : false
+
+		Result
+		"Address"#>'{State,City}'
+
+		--------------------------------------------
+
+		Expected columns ( unquote as text )
+		Column : Address
+		Nested : [ State, City ]
+		UnquoteResult : true
+
+		Result
+		"Address"#>>'{State,City}'
+	*/
+	x := it.(primitive.JSONColumn)
+	paths := make([]string, 0, len(x.Nested))
+	for _, n := range x.Nested {
+		n = strings.TrimPrefix(strings.TrimPrefix(n, "$"), ".")
+		if n == "" {
+			continue
+		}
+		paths = append(paths, strings.Split(n, ".")...)
+	}
+	operator := "#>"
+	if x.UnquoteResult {
+		operator += ">"
+	}
+	stmt.WriteString(b.Quote(x.Column) + operator + b.Wrap("{"+strings.Join(paths, ",")+"}"))
+	return nil
+}
+
+// BuildInserted :
+func (b *postgresBuilder) BuildInserted(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Inserted)
+	stmt.WriteString("EXCLUDED." + b.Quote(x.Field))
+	return
+}
+
+// buildJSONB will cast the value to `jsonb` if it's not a column
+func (b *postgresBuilder) buildJSONB(stmt sqlstmt.Stmt, it interface{}) error {
+	switch it.(type) {
+	case primitive.Column, primitive.JSONColumn, primitive.JSONFunc, primitive.CastAs, primitive.Invalid:
+		return b.Parser.BuildStatement(stmt, it)
+	}
+	if err := b.GetValue(stmt, it); err != nil {
+		return err
+	}
+	stmt.WriteString("::JSONB")
+	return nil
+}
+
+// appendLimitedWhere : postgres doesn't support `ORDER BY` and `LIMIT` on `UPDATE` and `DELETE`,
+// so we limit the affected rows using the physical location of the row (ctid) instead.
+func (b *postgresBuilder) AppendLimitedWhere(stmt sqlstmt.Stmt, db, table string, conds []interface{}, sorts []interface{}, limit uint) error {
+	if limit < 1 && len(sorts) < 1 {
+		return b.AppendWhere(stmt, conds)
+	}
+	stmt.WriteString(" WHERE ctid = ANY(ARRAY(SELECT ctid FROM " + b.TableName(db, table))
+	if err := b.AppendWhere(stmt, conds); err != nil {
+		return err
+	}
+	if err := b.AppendOrderBy(stmt, sorts); err != nil {
+		return err
+	}
+	b.AppendLimitNOffset(stmt, limit, 0)
+	stmt.WriteString("))")
+	return nil
+}
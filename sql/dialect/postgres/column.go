@@ -0,0 +1,28 @@
+package postgres
+
+import sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+
+// GetColumns :
+func (pg *Postgres) GetColumns(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT c.ordinal_position, c.column_name, UPPER(pg_catalog.format_type(a.atttypid, a.atttypmod)), c.column_default, c.is_nullable,
+	c.data_type, c.character_set_name, c.collation_name, COALESCE(pg_catalog.col_description(a.attrelid, a.attnum), ''),
+	CASE WHEN c.is_identity = 'YES' THEN 'IDENTITY' WHEN c.is_generated = 'ALWAYS' THEN 'STORED GENERATED' ELSE '' END
+	FROM information_schema.columns AS c JOIN pg_catalog.pg_attribute AS a
+	ON a.attrelid = (quote_ident(c.table_schema) || '.' || quote_ident(c.table_name))::regclass AND a.attname = c.column_name
+	WHERE c.table_schema = $1 AND c.table_name = $2 ORDER BY c.ordinal_position;`)
+	stmt.AppendArgs(dbName, table)
+}
+
+// RenameColumn :
+func (pg *Postgres) RenameColumn(stmt sqlstmt.Stmt, db, table, oldColName, newColName string) {
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table))
+	stmt.WriteString(" RENAME COLUMN " + pg.Quote(oldColName) + " TO " + pg.Quote(newColName))
+	stmt.WriteByte(';')
+}
+
+// DropColumn :
+func (pg *Postgres) DropColumn(stmt sqlstmt.Stmt, db, table, column string) {
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table))
+	stmt.WriteString(" DROP COLUMN " + pg.Quote(column))
+	stmt.WriteByte(';')
+}
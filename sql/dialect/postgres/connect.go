@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"strings"
+
+	"github.com/si3nloong/sqlike/sql/charset"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/si3nloong/sqlike/util"
+)
+
+// Connect : build the keyword/value connection string which is supported by both `lib/pq` and `pgx`
+func (pg Postgres) Connect(opt *options.ConnectOptions) (connStr string) {
+	if opt.RawConnStr() != "" {
+		connStr = opt.RawConnStr()
+		return
+	}
+
+	if opt.Username == "" {
+		panic("missing username for db connection")
+	}
+
+	blr := util.AcquireString()
+	defer util.ReleaseString(blr)
+	blr.WriteString("user=" + quoteConnValue(opt.Username))
+	if opt.Password != "" {
+		blr.WriteString(" password=" + quoteConnValue(opt.Password))
+	}
+	if opt.Socket != "" {
+		// unix domain socket is a directory path on postgres
+		blr.WriteString(" host=" + quoteConnValue(opt.Socket))
+	} else if opt.Host != "" {
+		blr.WriteString(" host=" + quoteConnValue(opt.Host))
+	}
+	if opt.Port != "" {
+		blr.WriteString(" port=" + opt.Port)
+	}
+	switch opt.Charset {
+	case "", charset.UTF8MB4, charset.UTF8:
+		blr.WriteString(" client_encoding=UTF8")
+	default:
+		blr.WriteString(" client_encoding=" + strings.ToUpper(string(opt.Charset)))
+	}
+	connStr = blr.String()
+	return
+}
+
+func quoteConnValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	v = strings.ReplaceAll(v, `\`, `\\`)
+	v = strings.ReplaceAll(v, `'`, `\'`)
+	return "'" + v + "'"
+}
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConnect :
+func TestConnect(t *testing.T) {
+	var (
+		pg  = Postgres{}
+		str string
+	)
+
+	str = pg.Connect(&options.ConnectOptions{
+		Username: "postgres",
+		Password: "it's secret",
+		Host:     "localhost",
+		Port:     "5432",
+	})
+	require.Equal(t, `user=postgres password='it\'s secret' host=localhost port=5432 client_encoding=UTF8`, str)
+
+	uri := `postgres://postgres:@localhost:5432/postgres?sslmode=disable`
+	opt := new(options.ConnectOptions)
+	str = pg.Connect(opt.ApplyURI(uri))
+	require.Equal(t, uri, str)
+
+	require.Panics(t, func() {
+		opt := new(options.ConnectOptions)
+		pg.Connect(opt)
+	})
+}
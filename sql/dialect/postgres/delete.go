@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+)
+
+// Delete :
+func (pg *Postgres) Delete(stmt sqlstmt.Stmt, f *actions.DeleteActions) (err error) {
+	err = buildStatement(stmt, pg.parser, f)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"errors"
+	"strings"
+
+	sqlerrors "github.com/si3nloong/sqlike/sqlike/errors"
+)
+
+// postgres error codes :
+const (
+	errStringDataRightTruncation = "22001"
+	errForeignKeyViolation       = "23503"
+	errUniqueViolation           = "23505"
+	errSerializationFailure      = "40001"
+	errDeadlockDetected          = "40P01"
+	errUndefinedTable            = "42P01"
+	errLockNotAvailable          = "55P03"
+)
+
+// sqlState : both `lib/pq` and `jackc/pgx` errors implement this
+type sqlState interface {
+	SQLState() string
+}
+
+// IsRetryable : whether the transaction is able to succeed by retrying, such as serialization failure, deadlock and lock timeout
+func (pg Postgres) IsRetryable(err error) bool {
+	var e sqlState
+	if !errors.As(err, &e) {
+		return false
+	}
+	switch e.SQLState() {
+	case errSerializationFailure, errDeadlockDetected, errLockNotAvailable:
+		return true
+	}
+	return false
+}
+
+// MapError : map the postgres error code to portable error, the error is returned as it is if it's not mapped
+func (pg Postgres) MapError(err error) error {
+	var e sqlState
+	if !errors.As(err, &e) {
+		return err
+	}
+	var pe *sqlerrors.Error
+	if errors.As(err, &pe) {
+		return err
+	}
+	switch e.SQLState() {
+	case errUniqueViolation:
+		return sqlerrors.New(sqlerrors.ErrDuplicateKey, err).WithIndex(constraintName(err.Error()))
+	case errForeignKeyViolation:
+		return sqlerrors.New(sqlerrors.ErrForeignKeyViolation, err).WithIndex(constraintName(err.Error()))
+	case errDeadlockDetected:
+		return sqlerrors.New(sqlerrors.ErrDeadlock, err)
+	case errLockNotAvailable:
+		return sqlerrors.New(sqlerrors.ErrLockWaitTimeout, err)
+	case errStringDataRightTruncation:
+		return sqlerrors.New(sqlerrors.ErrDataTooLong, err)
+	case errUndefinedTable:
+		return sqlerrors.New(sqlerrors.ErrTableNotFound, err)
+	}
+	return err
+}
+
+// constraintName : the constraint name is quoted in message, eg. `duplicate key value violates unique constraint "users_pkey"`
+func constraintName(msg string) string {
+	i := strings.Index(msg, "constraint \"")
+	if i < 0 {
+		return ""
+	}
+	msg = msg[i+len("constraint \""):]
+	j := strings.Index(msg, "\"")
+	if j < 0 {
+		return ""
+	}
+	return msg[:j]
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"errors"
+	"testing"
+
+	sqlerrors "github.com/si3nloong/sqlike/sqlike/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type pgError struct {
+	code string
+	msg  string
+}
+
+func (e pgError) Error() string    { return "pq: " + e.msg }
+func (e pgError) SQLState() string { return e.code }
+
+func TestMapError(t *testing.T) {
+	pg := Postgres{}
+	require.Nil(t, pg.MapError(nil))
+
+	err := pg.MapError(pgError{code: "23505", msg: `duplicate key value violates unique constraint "users_email_key"`})
+	require.True(t, errors.Is(err, sqlerrors.ErrDuplicateKey))
+	var e *sqlerrors.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, "users_email_key", e.Index)
+
+	err = pg.MapError(pgError{code: "23503", msg: `insert or update on table "orders" violates foreign key constraint "FK_abc"`})
+	require.True(t, errors.Is(err, sqlerrors.ErrForeignKeyViolation))
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, "FK_abc", e.Index)
+
+	require.True(t, errors.Is(pg.MapError(pgError{code: "40P01"}), sqlerrors.ErrDeadlock))
+	require.True(t, errors.Is(pg.MapError(pgError{code: "55P03"}), sqlerrors.ErrLockWaitTimeout))
+	require.True(t, errors.Is(pg.MapError(pgError{code: "22001"}), sqlerrors.ErrDataTooLong))
+	require.True(t, errors.Is(pg.MapError(pgError{code: "42P01"}), sqlerrors.ErrTableNotFound))
+	require.Equal(t, pgError{code: "42601"}, pg.MapError(pgError{code: "42601"}))
+
+	require.True(t, pg.IsRetryable(pgError{code: "40001"}))
+	require.False(t, pg.IsRetryable(pgError{code: "23505"}))
+}
@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"strings"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+)
+
+// GetForeignKeys : every column of the foreign key will be a row, ordered by constraint name and column position
+func (pg Postgres) GetForeignKeys(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("SELECT tc.constraint_name, kcu.column_name, ccu.table_name, ccu.column_name, rc.delete_rule, rc.update_rule ")
+	stmt.WriteString("FROM information_schema.table_constraints AS tc ")
+	stmt.WriteString("INNER JOIN information_schema.key_column_usage AS kcu ON kcu.constraint_schema = tc.constraint_schema AND kcu.constraint_name = tc.constraint_name ")
+	stmt.WriteString("INNER JOIN information_schema.referential_constraints AS rc ON rc.constraint_schema = tc.constraint_schema AND rc.constraint_name = tc.constraint_name ")
+	stmt.WriteString("INNER JOIN information_schema.key_column_usage AS ccu ON ccu.constraint_schema = rc.unique_constraint_schema AND ccu.constraint_name = rc.unique_constraint_name AND ccu.ordinal_position = kcu.position_in_unique_constraint ")
+	stmt.WriteString("WHERE tc.table_schema = $1 AND tc.table_name = $2 AND tc.constraint_type = 'FOREIGN KEY' ORDER BY tc.constraint_name, kcu.ordinal_position;")
+	stmt.AppendArgs(db, table)
+}
+
+// CreateForeignKeys :
+func (pg Postgres) CreateForeignKeys(stmt sqlstmt.Stmt, db, table string, fks []foreignkeys.ForeignKey) (err error) {
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table) + " ")
+	for i, fk := range fks {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString("ADD ")
+		pg.buildForeignKey(stmt, db, table, fk)
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// DropForeignKeys :
+func (pg Postgres) DropForeignKeys(stmt sqlstmt.Stmt, db, table string, names []string) (err error) {
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table) + " ")
+	for i, name := range names {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString("DROP CONSTRAINT " + pg.Quote(name))
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+func (pg Postgres) buildForeignKey(stmt sqlstmt.Stmt, db, table string, fk foreignkeys.ForeignKey) {
+	stmt.WriteString("CONSTRAINT " + pg.Quote(fk.GetName(table)) + " FOREIGN KEY (")
+	stmt.WriteString(pg.quoteColumns(fk.Columns))
+	stmt.WriteString(") REFERENCES " + pg.TableName(db, fk.RefTable) + " (")
+	stmt.WriteString(pg.quoteColumns(fk.RefColumns))
+	stmt.WriteByte(')')
+	if fk.OnDelete != "" {
+		stmt.WriteString(" ON DELETE " + string(fk.OnDelete))
+	}
+	if fk.OnUpdate != "" {
+		stmt.WriteString(" ON UPDATE " + string(fk.OnUpdate))
+	}
+}
+
+func (pg Postgres) quoteColumns(cols []string) string {
+	blr := new(strings.Builder)
+	for i, col := range cols {
+		if i > 0 {
+			blr.WriteByte(',')
+		}
+		blr.WriteString(pg.Quote(col))
+	}
+	return blr.String()
+}
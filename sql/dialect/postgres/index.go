@@ -0,0 +1,148 @@
+package postgres
+
+import (
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/indexes"
+)
+
+// HasIndexByName :
+func (pg Postgres) HasIndexByName(stmt sqlstmt.Stmt, dbName, table, indexName string) {
+	stmt.WriteString(`SELECT COUNT(1) FROM pg_indexes WHERE schemaname = $1 AND tablename = $2 AND indexname = $3;`)
+	stmt.AppendArgs(dbName, table, indexName)
+}
+
+// HasIndex :
+func (pg Postgres) HasIndex(stmt sqlstmt.Stmt, dbName, table string, idx indexes.Index) {
+	unique := false
+	switch idx.Type {
+	case indexes.Unique, indexes.Primary:
+		unique = true
+	}
+	args := []interface{}{dbName, table, pg.getIndexMethod(idx.Type), unique}
+	stmt.WriteString("SELECT COUNT(1) FROM (")
+	stmt.WriteString("SELECT i.relname, COUNT(*) AS c FROM pg_index AS ix ")
+	stmt.WriteString("JOIN pg_class AS i ON i.oid = ix.indexrelid ")
+	stmt.WriteString("JOIN pg_class AS t ON t.oid = ix.indrelid ")
+	stmt.WriteString("JOIN pg_namespace AS n ON n.oid = t.relnamespace ")
+	stmt.WriteString("JOIN pg_am AS am ON am.oid = i.relam ")
+	stmt.WriteString("JOIN pg_attribute AS a ON a.attrelid = t.oid AND a.attnum = ANY(ix.indkey) ")
+	stmt.WriteString("WHERE n.nspname = $1 ")
+	stmt.WriteString("AND t.relname = $2 ")
+	stmt.WriteString("AND am.amname = $3 ")
+	stmt.WriteString("AND ix.indisunique = $4 ")
+	stmt.WriteString("AND a.attname IN ")
+	stmt.WriteByte('(')
+	for i, col := range idx.Columns {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		args = append(args, col.Name)
+		stmt.WriteString(pg.Var(len(args)))
+	}
+	stmt.WriteByte(')')
+	stmt.WriteString(" GROUP BY i.relname")
+	args = append(args, int64(len(idx.Columns)))
+	stmt.WriteString(") AS temp WHERE temp.c = " + pg.Var(len(args)))
+	stmt.WriteByte(';')
+	stmt.AppendArgs(args...)
+}
+
+// GetIndexes :
+func (pg Postgres) GetIndexes(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT indexname, UPPER(SUBSTRING(indexdef FROM 'USING (\w+)')), indexdef NOT LIKE 'CREATE UNIQUE%' FROM pg_indexes WHERE schemaname = $1 AND tablename = $2;`)
+	stmt.AppendArgs(dbName, table)
+}
+
+// CreateIndexes : postgres doesn't support adding index using `ALTER TABLE`, so every index will be a `CREATE INDEX` statement.
+func (pg Postgres) CreateIndexes(stmt sqlstmt.Stmt, db, table string, idxs []indexes.Index, supportDesc bool) {
+	for _, idx := range idxs {
+		name := pg.indexName(table, idx)
+		if idx.Type == indexes.Primary {
+			stmt.WriteString("ALTER TABLE " + pg.TableName(db, table) + " ADD PRIMARY KEY (")
+			for j, col := range idx.Columns {
+				if j > 0 {
+					stmt.WriteByte(',')
+				}
+				stmt.WriteString(pg.Quote(col.Name))
+			}
+			stmt.WriteString(");")
+			continue
+		}
+
+		stmt.WriteString("CREATE ")
+		if idx.Type == indexes.Unique {
+			stmt.WriteString("UNIQUE ")
+		}
+		stmt.WriteString("INDEX " + pg.Quote(name) + " ON " + pg.TableName(db, table))
+		stmt.WriteString(" USING " + pg.getIndexMethod(idx.Type) + " (")
+		switch idx.Type {
+		case indexes.MultiValued:
+			stmt.WriteString("(" + pg.Quote(idx.Cast) + "::JSONB)")
+		case indexes.FullText:
+			stmt.WriteString("TO_TSVECTOR('simple', ")
+			for j, col := range idx.Columns {
+				if j > 0 {
+					stmt.WriteString(" || ' ' || ")
+				}
+				stmt.WriteString("COALESCE(" + pg.Quote(col.Name) + ", '')")
+			}
+			stmt.WriteByte(')')
+		default:
+			for j, col := range idx.Columns {
+				if j > 0 {
+					stmt.WriteByte(',')
+				}
+				stmt.WriteString(pg.Quote(col.Name))
+				if !supportDesc {
+					continue
+				}
+				if col.Direction == indexes.Descending {
+					stmt.WriteString(" DESC")
+				}
+			}
+		}
+		stmt.WriteString(");")
+
+		if idx.Comment != "" {
+			stmt.WriteString("COMMENT ON INDEX " + pg.TableName(db, name) + " IS " + pg.Wrap(idx.Comment) + ";")
+		}
+	}
+}
+
+// DropIndexes :
+func (pg Postgres) DropIndexes(stmt sqlstmt.Stmt, db, table string, idxs []string) {
+	for _, idx := range idxs {
+		// primary key and unique constraint should be dropped using `ALTER TABLE ... DROP CONSTRAINT`
+		stmt.WriteString("DROP INDEX IF EXISTS " + pg.TableName(db, idx) + ";")
+	}
+}
+
+// indexName : index name in postgres is unique across the schema, so the generated name will be prefixed by table name
+func (pg Postgres) indexName(table string, idx indexes.Index) string {
+	if idx.Name != "" {
+		return idx.Name
+	}
+	return table + "_" + idx.HashName()
+}
+
+func (pg Postgres) getIndexMethod(k indexes.Type) (method string) {
+	switch k {
+	case indexes.FullText, indexes.MultiValued:
+		method = "gin"
+	case indexes.Spatial:
+		method = "gist"
+	default:
+		method = "btree"
+	}
+	return
+}
+
+// UniqueIndexName : name of the unique index which created by `unique_index` tag
+func (pg Postgres) UniqueIndexName(table, column string) string {
+	return pg.indexName(table, indexes.Index{Type: indexes.Unique, Columns: indexes.Columns(column)})
+}
+
+// UniqueAutoIncrement : whether `AlterTable` creates an unique index on the `auto_increment` column
+func (pg Postgres) UniqueAutoIncrement() bool {
+	return true
+}
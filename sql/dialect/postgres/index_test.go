@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"testing"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/indexes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasIndexByName(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+	pg.HasIndexByName(stmt, "db", "table", "idx1")
+	require.Equal(t, `SELECT COUNT(1) FROM pg_indexes WHERE schemaname = $1 AND tablename = $2 AND indexname = $3;`, stmt.String())
+	require.ElementsMatch(t, []interface{}{"db", "table", "idx1"}, stmt.Args())
+}
+
+func TestCreateIndexes(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.CreateIndexes(stmt, "db", "table", []indexes.Index{
+		{Name: "idx_name", Type: indexes.Unique, Columns: indexes.Columns("Name", "Email")},
+	}, true)
+	require.Equal(t, `CREATE UNIQUE INDEX "idx_name" ON "db"."table" USING btree ("Name","Email");`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestDropIndexes(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.DropIndexes(stmt, "db", "table", []string{"idx1"})
+	require.Equal(t, `DROP INDEX IF EXISTS "db"."idx1";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
@@ -0,0 +1,267 @@
+package postgres
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/spatial"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/driver"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
+)
+
+// ValidateInsert : the inserted row of `ON CONFLICT` is always referred by `EXCLUDED`, so every version is supported
+func (pg Postgres) ValidateInsert(info driver.Info, opt *options.InsertOptions) error {
+	return nil
+}
+
+// InsertInto :
+func (pg Postgres) InsertInto(stmt sqlstmt.Stmt, db, table, pk string, cache reflext.StructMapper, cdc codec.Codecer, fields []reflext.StructFielder, v reflect.Value, opt *options.InsertOptions) (err error) {
+	records := v.Len()
+
+	stmt.WriteString("INSERT INTO " + pg.TableName(db, table) + " (")
+
+	omitField := make(map[string]bool)
+	noOfOmit := len(opt.Omits)
+	for i := 0; i < len(fields); {
+		// omit all the field provided by user
+		if noOfOmit > 0 && opt.Omits.IndexOf(fields[i].Name()) > -1 {
+			if opt.Mode != options.InsertOnDuplicate {
+				fields = append(fields[:i], fields[i+1:]...)
+				continue
+			} else {
+				omitField[fields[i].Name()] = true
+			}
+		}
+
+		// omit all the struct field with `generated_column` tag, it shouldn't include when inserting to the db
+		if _, ok := fields[i].Tag().LookUp("generated_column"); ok {
+			fields = append(fields[:i], fields[i+1:]...)
+			continue
+		}
+
+		stmt.WriteString(pg.Quote(fields[i].Name()))
+		if i < len(fields)-1 {
+			stmt.WriteByte(',')
+		}
+
+		i++
+	}
+	stmt.WriteString(") VALUES ")
+
+	length := len(fields)
+	encoders := make([]codec.ValueEncoder, length)
+	for i := 0; i < records; i++ {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteByte('(')
+		vi := reflext.Indirect(v.Index(i))
+
+		for j := range fields {
+			if j > 0 {
+				stmt.WriteByte(',')
+			}
+
+			fv := cache.FieldByIndexesReadOnly(vi, fields[j].Index())
+			// identity column should use the sequence value if it's empty
+			if _, ok := fields[j].Tag().LookUp("auto_increment"); ok && reflext.IsZero(fv) {
+				stmt.WriteString("DEFAULT")
+				continue
+			}
+
+			if encoders[j] == nil {
+				encoders[j], err = cdc.LookupEncoder(fv)
+				if err != nil {
+					return err
+				}
+			}
+
+			val, err := encoders[j](fields[j], fv)
+			if err != nil {
+				return err
+			}
+
+			convertSpatial(stmt, pg.PostgresUtil, val)
+		}
+		stmt.WriteByte(')')
+	}
+
+	switch opt.Mode {
+	case options.InsertIgnore:
+		stmt.WriteString(" ON CONFLICT DO NOTHING")
+	case options.InsertOnDuplicate:
+		var target []string
+		for _, f := range fields {
+			if _, ok := f.Tag().LookUp("primary_key"); ok {
+				target = []string{f.Name()}
+				break
+			}
+			if f.Name() == pk {
+				target = []string{pk}
+			}
+		}
+		if err := pg.buildOnConflict(stmt, target, updatableColumns(fields, pk, omitField), opt.OnConflict); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// InsertFrom : `INSERT INTO ... SELECT`
+func (pg Postgres) InsertFrom(stmt sqlstmt.Stmt, db, table, pk string, columns []string, query *sql.SelectStmt, opt *options.InsertOptions) (err error) {
+	stmt.WriteString("INSERT")
+	stmt.WriteString(" INTO " + pg.TableName(db, table) + " ")
+	if len(columns) > 0 {
+		stmt.WriteByte('(')
+		for i, col := range columns {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(pg.Quote(col))
+		}
+		stmt.WriteString(") ")
+	}
+	if err := pg.parser.BuildStatement(stmt, query); err != nil {
+		return err
+	}
+	switch opt.Mode {
+	case options.InsertIgnore:
+		stmt.WriteString(" ON CONFLICT DO NOTHING")
+	case options.InsertOnDuplicate:
+		// the inserted columns except primary key and omitted fields will be updated by default
+		updates := make([]string, 0, len(columns))
+		for _, col := range columns {
+			if col == pk || opt.Omits.IndexOf(col) > -1 {
+				continue
+			}
+			updates = append(updates, col)
+		}
+		// without primary key and conflict target, the conflict on any unique constraint is ignored
+		var target []string
+		if pk != "" {
+			target = []string{pk}
+		}
+		if err := pg.buildOnConflict(stmt, target, updates, opt.OnConflict); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// buildOnConflict : the conflict target is the primary key if it's not specified
+func (pg Postgres) buildOnConflict(stmt sqlstmt.Stmt, target []string, columns []string, opt *options.OnConflictOptions) error {
+	if opt == nil {
+		opt = options.OnConflict()
+	}
+	if len(opt.Target) > 0 {
+		target = opt.Target
+	}
+	if len(target) < 1 {
+		stmt.WriteString(" ON CONFLICT DO NOTHING")
+		return nil
+	}
+
+	stmt.WriteString(" ON CONFLICT (")
+	for i, col := range target {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString(pg.Quote(col))
+	}
+	stmt.WriteString(") DO UPDATE SET ")
+
+	values := make([]primitive.KV, 0, len(columns))
+	for _, name := range onConflictColumns(columns, opt) {
+		// the conflict target won't be updated
+		if sqlutil.StringSlice(target).IndexOf(name) > -1 {
+			continue
+		}
+		values = append(values, primitive.KV{Field: name, Value: primitive.Inserted{Field: name}})
+	}
+	values = append(values, opt.Values...)
+	if len(values) < 1 {
+		// nothing to update, fallback to conflict target so the statement stays valid
+		values = append(values, primitive.KV{Field: target[0], Value: primitive.Inserted{Field: target[0]}})
+	}
+
+	for i, kv := range values {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString(pg.Quote(kv.Field) + "=")
+		if err := pg.buildValue(stmt, kv.Value); err != nil {
+			return err
+		}
+	}
+	if len(opt.Conditions.Values) > 0 {
+		stmt.WriteString(" WHERE ")
+		if err := pg.parser.BuildStatement(stmt, opt.Conditions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updatableColumns : every column except primary key and omitted fields will be updated with the inserted value by default
+func updatableColumns(fields []reflext.StructFielder, pk string, omits map[string]bool) []string {
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		name := f.Name()
+		if name == pk {
+			continue
+		}
+		if _, ok := f.Tag().LookUp("auto_increment"); ok {
+			continue
+		}
+		if _, ok := omits[name]; ok {
+			continue
+		}
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// onConflictColumns : the columns which will be updated with the inserted value
+func onConflictColumns(columns []string, opt *options.OnConflictOptions) []string {
+	if len(opt.Columns) > 0 {
+		return opt.Columns
+	}
+	if len(opt.Values) > 0 {
+		return nil
+	}
+	return columns
+}
+
+// buildValue : the value which doesn't have builder will be bound as argument
+func (pg Postgres) buildValue(stmt sqlstmt.Stmt, it interface{}) error {
+	if v := reflext.ValueOf(it); v.IsValid() {
+		if _, ok := pg.parser.LookupBuilder(v.Type()); ok {
+			return pg.parser.BuildStatement(stmt, it)
+		}
+	}
+	return pg.parser.BuildStatement(stmt, primitive.Value{Raw: it})
+}
+
+func convertSpatial(stmt sqlstmt.Stmt, utl sqlutil.PostgresUtil, val interface{}) {
+	switch vi := val.(type) {
+	case spatial.Geometry:
+		stmt.AppendArgs(vi.WKT)
+		stmt.WriteString("ST_GeomFromText(" + utl.Var(len(stmt.Args())))
+		if vi.SRID > 0 {
+			stmt.WriteString("," + strconv.FormatUint(uint64(vi.SRID), 10))
+		}
+		stmt.WriteByte(')')
+
+	default:
+		stmt.AppendArgs(val)
+		stmt.WriteString(utl.Var(len(stmt.Args())))
+	}
+}
@@ -0,0 +1,92 @@
+package postgres
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/expr"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type insertStruct struct {
+	ID    int64 `sqlike:",primary_key"`
+	Email string
+	Count int
+}
+
+func TestInsertOnConflict(t *testing.T) {
+	var (
+		pg   = New()
+		data = []insertStruct{{ID: 1, Email: "john@example.com", Count: 1}}
+		v    = reflect.ValueOf(data)
+		cdc  = reflext.DefaultMapper.CodecByType(v.Type().Elem())
+	)
+
+	build := func(opt *options.InsertOptions) string {
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := pg.InsertInto(stmt, "public", "users", "ID", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, opt)
+		require.NoError(t, err)
+		return stmt.String()
+	}
+
+	require.Equal(t, `INSERT INTO "public"."users" ("ID","Email","Count") VALUES ($1,$2,$3) ON CONFLICT ("ID") DO UPDATE SET "Email"=EXCLUDED."Email","Count"=EXCLUDED."Count";`, build(options.Insert().SetMode(options.InsertOnDuplicate)))
+
+	require.Equal(t, `INSERT INTO "public"."users" ("ID","Email","Count") VALUES ($1,$2,$3) ON CONFLICT ("Email") DO UPDATE SET "Count"="Count" + 1 WHERE "Count" < $4;`, build(options.Insert().SetOnConflict(
+		options.OnConflict().
+			SetTarget("Email").
+			SetValues(expr.ColumnValue("Count", expr.Increment("Count", 1))).
+			SetWhere(expr.LesserThan("Count", 100)),
+	)))
+
+	// the conflict target won't be updated
+	require.Equal(t, `INSERT INTO "public"."users" ("ID","Email","Count") VALUES ($1,$2,$3) ON CONFLICT ("Email") DO UPDATE SET "Count"=EXCLUDED."Count";`, build(options.Insert().SetOnConflict(
+		options.OnConflict().SetTarget("Email"),
+	)))
+}
+
+func TestInsertFrom(t *testing.T) {
+	var (
+		pg    = New()
+		query = sql.Select("ID", "Email", "Count").
+			From("db", "archived_users").
+			Where(expr.GreaterThan("Count", 0))
+	)
+
+	build := func(opt *options.InsertOptions) string {
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := pg.InsertFrom(stmt, "db", "users", "ID", []string{"ID", "Email", "Count"}, query, opt)
+		require.NoError(t, err)
+		return stmt.String()
+	}
+
+	t.Run("Insert ignore", func(ti *testing.T) {
+		sql := build(options.Insert().SetMode(options.InsertIgnore))
+		require.Equal(ti, `INSERT INTO "db"."users" ("ID","Email","Count") SELECT "ID","Email","Count" FROM "db"."archived_users" WHERE "Count" > $1 ON CONFLICT DO NOTHING;`, sql)
+	})
+
+	t.Run("On conflict", func(ti *testing.T) {
+		sql := build(options.Insert().SetMode(options.InsertOnDuplicate))
+		require.Equal(ti, `INSERT INTO "db"."users" ("ID","Email","Count") SELECT "ID","Email","Count" FROM "db"."archived_users" WHERE "Count" > $1 ON CONFLICT ("ID") DO UPDATE SET "Email"=EXCLUDED."Email","Count"=EXCLUDED."Count";`, sql)
+	})
+
+	t.Run("On conflict without primary key", func(ti *testing.T) {
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := pg.InsertFrom(stmt, "db", "users", "", []string{"Email", "Count"}, query, options.Insert().SetMode(options.InsertOnDuplicate))
+		require.NoError(ti, err)
+		require.Equal(ti, `INSERT INTO "db"."users" ("Email","Count") SELECT "ID","Email","Count" FROM "db"."archived_users" WHERE "Count" > $1 ON CONFLICT DO NOTHING;`, stmt.String())
+
+		// the conflict target is specified
+		stmt.Reset()
+		err = pg.InsertFrom(stmt, "db", "users", "", []string{"Email", "Count"}, query, options.Insert().SetOnConflict(options.OnConflict().SetTarget("Email")))
+		require.NoError(ti, err)
+		require.Equal(ti, `INSERT INTO "db"."users" ("Email","Count") SELECT "ID","Email","Count" FROM "db"."archived_users" WHERE "Count" > $1 ON CONFLICT ("Email") DO UPDATE SET "Count"=EXCLUDED."Count";`, stmt.String())
+	})
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/dialect"
+	"github.com/si3nloong/sqlike/sql/schema"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+)
+
+// Postgres : the postgres dialect. As a postgres connection is always bound to a single database,
+// the sqlike `Database` is mapped to a postgres schema.
+type Postgres struct {
+	schema *schema.Builder
+	parser *sqlstmt.StatementBuilder
+	sqlutil.PostgresUtil
+}
+
+var _ dialect.Dialect = (*(Postgres))(nil)
+
+// New :
+func New() *Postgres {
+	sb := schema.NewBuilder()
+	pr := sqlstmt.NewStatementBuilder()
+
+	postgresSchema{}.SetBuilders(sb)
+	postgresBuilder{}.SetRegistryAndBuilders(codec.DefaultRegistry, pr)
+
+	return &Postgres{
+		schema: sb,
+		parser: pr,
+	}
+}
+
+// GetVersion :
+func (pg Postgres) GetVersion(stmt sqlstmt.Stmt) {
+	stmt.WriteString("SELECT SPLIT_PART(CURRENT_SETTING('server_version'), ' ', 1);")
+}
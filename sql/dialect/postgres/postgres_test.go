@@ -0,0 +1,17 @@
+package postgres
+
+import (
+	"testing"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersion(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+	pg.GetVersion(stmt)
+	require.Equal(t, "SELECT SPLIT_PART(CURRENT_SETTING('server_version'), ' ', 1);", stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
@@ -0,0 +1,13 @@
+package postgres
+
+import (
+	"errors"
+
+	"github.com/si3nloong/sqlike/sql"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+)
+
+// Replace : postgres doesn't have `REPLACE INTO`, use `InsertInto` with `options.InsertOnDuplicate` instead.
+func (pg Postgres) Replace(stmt sqlstmt.Stmt, db, table string, columns []string, query *sql.SelectStmt) (err error) {
+	return errors.New("postgres: replace into is not supported")
+}
@@ -0,0 +1,20 @@
+package postgres
+
+import (
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+)
+
+// Savepoint :
+func (pg Postgres) Savepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("SAVEPOINT " + pg.Quote(name) + ";")
+}
+
+// RollbackToSavepoint :
+func (pg Postgres) RollbackToSavepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("ROLLBACK TO SAVEPOINT " + pg.Quote(name) + ";")
+}
+
+// ReleaseSavepoint :
+func (pg Postgres) ReleaseSavepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("RELEASE SAVEPOINT " + pg.Quote(name) + ";")
+}
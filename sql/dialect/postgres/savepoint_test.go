@@ -0,0 +1,25 @@
+package postgres
+
+import (
+	"testing"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavepoint(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.Savepoint(stmt, "sp_1")
+	require.Equal(t, `SAVEPOINT "sp_1";`, stmt.String())
+
+	stmt.Reset()
+	pg.RollbackToSavepoint(stmt, "sp_1")
+	require.Equal(t, `ROLLBACK TO SAVEPOINT "sp_1";`, stmt.String())
+
+	stmt.Reset()
+	pg.ReleaseSavepoint(stmt, "sp_1")
+	require.Equal(t, `RELEASE SAVEPOINT "sp_1";`, stmt.String())
+}
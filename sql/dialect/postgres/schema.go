@@ -0,0 +1,382 @@
+package postgres
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql/schema"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqltype "github.com/si3nloong/sqlike/sql/type"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/columns"
+	"github.com/si3nloong/sqlike/util"
+	"golang.org/x/text/currency"
+)
+
+// postgresSchema :
+type postgresSchema struct {
+	sqlutil.PostgresUtil
+}
+
+// SetBuilders :
+func (s postgresSchema) SetBuilders(sb *schema.Builder) {
+	sb.SetTypeBuilder(sqltype.Byte, s.ByteDataType)
+	sb.SetTypeBuilder(sqltype.Date, s.DateDataType)
+	sb.SetTypeBuilder(sqltype.Time, s.TimeDataType)
+	sb.SetTypeBuilder(sqltype.DateTime, s.DateTimeDataType)
+	sb.SetTypeBuilder(sqltype.Timestamp, s.DateTimeDataType)
+	sb.SetTypeBuilder(sqltype.UUID, s.UUIDDataType)
+	sb.SetTypeBuilder(sqltype.JSON, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Point, s.SpatialDataType("POINT"))
+	sb.SetTypeBuilder(sqltype.LineString, s.SpatialDataType("LINESTRING"))
+	sb.SetTypeBuilder(sqltype.Polygon, s.SpatialDataType("POLYGON"))
+	sb.SetTypeBuilder(sqltype.MultiPoint, s.SpatialDataType("MULTIPOINT"))
+	sb.SetTypeBuilder(sqltype.MultiLineString, s.SpatialDataType("MULTILINESTRING"))
+	sb.SetTypeBuilder(sqltype.MultiPolygon, s.SpatialDataType("MULTIPOLYGON"))
+	sb.SetTypeBuilder(sqltype.String, s.StringDataType)
+	sb.SetTypeBuilder(sqltype.Char, s.CharDataType)
+	sb.SetTypeBuilder(sqltype.Bool, s.BoolDataType)
+	sb.SetTypeBuilder(sqltype.Int, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int8, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int16, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int32, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int64, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Uint, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint8, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint16, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint32, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint64, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Float32, s.FloatDataType)
+	sb.SetTypeBuilder(sqltype.Float64, s.FloatDataType)
+	sb.SetTypeBuilder(sqltype.Struct, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Array, s.ArrayDataType)
+	sb.SetTypeBuilder(sqltype.Slice, s.JSONDataType)
+	sb.SetTypeBuilder(sqltype.Map, s.JSONDataType)
+}
+
+func (s postgresSchema) ByteDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "BYTEA"
+	col.Type = "BYTEA"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+func (s postgresSchema) UUIDDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "UUID"
+	col.Type = "UUID"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+func (s postgresSchema) DateDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "DATE"
+	col.Type = "DATE"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+func (s postgresSchema) TimeDataType(sf reflext.StructFielder) (col columns.Column) {
+	size := "6"
+	if v, exists := sf.Tag().LookUp("size"); exists {
+		if _, err := strconv.Atoi(v); err == nil {
+			size = v
+		}
+	}
+
+	col.Name = sf.Name()
+	col.DataType = "TIME"
+	col.Type = "TIME(" + size + ")"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+// DateTimeDataType : postgres doesn't support `ON UPDATE`, so the `on_update` tag is ignored
+func (s postgresSchema) DateTimeDataType(sf reflext.StructFielder) (col columns.Column) {
+	size := "6"
+	if v, exists := sf.Tag().LookUp("size"); exists {
+		if _, err := strconv.Atoi(v); err == nil {
+			size = v
+		}
+	}
+
+	dflt := "CURRENT_TIMESTAMP(" + size + ")"
+	col.Name = sf.Name()
+	col.DataType = "TIMESTAMP"
+	col.Type = "TIMESTAMP(" + size + ")"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s postgresSchema) JSONDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "JSONB"
+	col.Type = "JSONB"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+// SpatialDataType : spatial data type requires the `postgis` extension
+func (s postgresSchema) SpatialDataType(dataType string) schema.DataTypeFunc {
+	return func(sf reflext.StructFielder) (col columns.Column) {
+		col.Name = sf.Name()
+		col.DataType = "GEOMETRY"
+		col.Type = "GEOMETRY(" + dataType + ")"
+		if sf.Type().Kind() == reflect.Ptr {
+			col.Nullable = true
+		}
+		if v, ok := sf.Tag().LookUp("srid"); ok {
+			if _, err := strconv.ParseUint(v, 10, 64); err != nil {
+				return
+			}
+			col.Type = "GEOMETRY(" + dataType + "," + v + ")"
+		}
+		return
+	}
+}
+
+func (s postgresSchema) StringDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.Nullable = sf.IsNullable()
+
+	dflt := ""
+	tag := sf.Tag()
+	col.DefaultValue = &dflt
+	if v, ok := tag.LookUp("default"); ok {
+		col.DefaultValue = &v
+	}
+
+	if enum, ok := tag.LookUp("enum"); ok {
+		paths := strings.Split(enum, "|")
+		if len(paths) < 1 {
+			panic("invalid enum formats")
+		}
+
+		// postgres doesn't have inline enum, we use check constraint instead
+		blr := util.AcquireString()
+		defer util.ReleaseString(blr)
+		blr.WriteString("CHECK (")
+		blr.WriteString(s.Quote(col.Name))
+		blr.WriteString(" IN (")
+		for i, p := range paths {
+			if i > 0 {
+				blr.WriteRune(',')
+			}
+			blr.WriteString(s.Wrap(p))
+		}
+		blr.WriteString("))")
+
+		dflt = paths[0]
+		col.DataType = "VARCHAR"
+		col.Type = "VARCHAR(191)"
+		col.Extra = blr.String()
+		col.DefaultValue = &dflt
+		return
+	} else if char, ok := tag.LookUp("char"); ok {
+		if _, err := strconv.Atoi(char); err != nil {
+			panic("invalid value for char data type")
+		}
+		col.DataType = "CHAR"
+		col.Type = "CHAR(" + char + ")"
+		return
+	} else if _, ok := tag.LookUp("longtext"); ok {
+		col.DataType = "TEXT"
+		col.Type = "TEXT"
+		col.DefaultValue = nil
+		return
+	}
+
+	size, _ := tag.LookUp("size")
+	charLen, _ := strconv.Atoi(size)
+	if charLen < 1 {
+		charLen = 191
+	}
+
+	col.DataType = "VARCHAR"
+	col.Type = "VARCHAR(" + strconv.Itoa(charLen) + ")"
+	return
+}
+
+func (s postgresSchema) CharDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := ""
+	switch sf.Type() {
+	case reflect.TypeOf(currency.Unit{}):
+		col.Type = "CHAR(3)"
+	default:
+		col.Type = "CHAR(191)"
+	}
+	col.Name = sf.Name()
+	col.DataType = "CHAR"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s postgresSchema) BoolDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "false"
+	col.Name = sf.Name()
+	col.DataType = "BOOLEAN"
+	col.Type = "BOOLEAN"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s postgresSchema) IntDataType(sf reflext.StructFielder) (col columns.Column) {
+	t := sf.Type()
+	tag := sf.Tag()
+	dflt := "0"
+	dataType := s.getIntDataType(reflext.Deref(t))
+
+	col.Name = sf.Name()
+	col.DataType = dataType
+	col.Type = dataType
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if _, ok := tag.LookUp("auto_increment"); ok {
+		col.Extra = "GENERATED BY DEFAULT AS IDENTITY"
+		col.DefaultValue = nil
+	} else if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			panic("int default value should be integer")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+// UintDataType : postgres doesn't have unsigned integer, so we promote it to a larger integer type with a check constraint
+func (s postgresSchema) UintDataType(sf reflext.StructFielder) (col columns.Column) {
+	t := sf.Type()
+	tag := sf.Tag()
+	dflt := "0"
+	dataType := s.getUintDataType(reflext.Deref(t))
+
+	col.Name = sf.Name()
+	col.DataType = dataType
+	col.Type = dataType
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	col.Extra = "CHECK (" + s.Quote(col.Name) + " >= 0)"
+	if _, ok := tag.LookUp("auto_increment"); ok {
+		col.Extra = "GENERATED BY DEFAULT AS IDENTITY"
+		col.DefaultValue = nil
+	} else if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseUint(v, 10, 64); err != nil {
+			panic("uint default value should be unsigned integer")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s postgresSchema) FloatDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "0"
+	tag := sf.Tag()
+	col.Name = sf.Name()
+	col.DataType = "DOUBLE PRECISION"
+	if reflext.Deref(sf.Type()).Kind() == reflect.Float32 {
+		col.DataType = "REAL"
+	}
+	col.Type = col.DataType
+	if _, ok := tag.LookUp("unsigned"); ok {
+		col.Extra = "CHECK (" + s.Quote(col.Name) + " >= 0)"
+	}
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			panic("float default value should be decimal number")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s postgresSchema) ArrayDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.Nullable = sf.IsNullable()
+	t := sf.Type().Elem()
+	if t.Kind() == reflect.Uint8 {
+		col.DataType = "VARCHAR"
+		col.Type = "VARCHAR(36)"
+		return
+	}
+	col.DataType = "JSONB"
+	col.Type = "JSONB"
+	return
+}
+
+func (pg Postgres) buildSchemaByColumn(stmt sqlstmt.Stmt, col columns.Column) {
+	stmt.WriteString(pg.Quote(col.Name))
+	stmt.WriteString(" " + col.Type)
+	if col.Collation != nil {
+		stmt.WriteString(" COLLATE " + pg.Quote(*col.Collation))
+	}
+	if col.Extra != "" {
+		stmt.WriteString(" " + col.Extra)
+	}
+	if !col.Nullable {
+		stmt.WriteString(" NOT NULL")
+		if col.DefaultValue != nil {
+			stmt.WriteString(" DEFAULT " + pg.WrapOnlyValue(*col.DefaultValue))
+		}
+	}
+}
+
+// buildAlterColumn : postgres alter the column type, nullability and default value separately
+func (pg Postgres) buildAlterColumn(stmt sqlstmt.Stmt, col columns.Column) {
+	name := pg.Quote(col.Name)
+	stmt.WriteString("ALTER COLUMN " + name + " TYPE " + col.Type)
+	if col.Collation != nil {
+		stmt.WriteString(" COLLATE " + pg.Quote(*col.Collation))
+	}
+	stmt.WriteString(" USING " + name + "::" + col.Type)
+	stmt.WriteString(",ALTER COLUMN " + name)
+	if col.Nullable {
+		stmt.WriteString(" DROP NOT NULL")
+	} else {
+		stmt.WriteString(" SET NOT NULL")
+	}
+	// identity column doesn't have default value
+	if strings.Contains(col.Extra, "IDENTITY") {
+		return
+	}
+	stmt.WriteString(",ALTER COLUMN " + name)
+	if !col.Nullable && col.DefaultValue != nil {
+		stmt.WriteString(" SET DEFAULT " + pg.WrapOnlyValue(*col.DefaultValue))
+	} else {
+		stmt.WriteString(" DROP DEFAULT")
+	}
+}
+
+func (s postgresSchema) getIntDataType(t reflect.Type) (dataType string) {
+	switch t.Kind() {
+	case reflect.Int8, reflect.Int16:
+		dataType = "SMALLINT"
+	case reflect.Int32:
+		dataType = "INTEGER"
+	case reflect.Int64:
+		dataType = "BIGINT"
+	default:
+		dataType = "INTEGER"
+	}
+	return
+}
+
+func (s postgresSchema) getUintDataType(t reflect.Type) (dataType string) {
+	switch t.Kind() {
+	case reflect.Uint8:
+		dataType = "SMALLINT"
+	case reflect.Uint16:
+		dataType = "INTEGER"
+	default:
+		dataType = "BIGINT"
+	}
+	return
+}
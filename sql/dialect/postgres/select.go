@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	semver "github.com/Masterminds/semver/v3"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+var postgres95 = semver.MustParse("9.5.0")
+
+// Select :
+func (pg *Postgres) Select(stmt sqlstmt.Stmt, f *actions.FindActions, lck options.LockMode, of ...string) (err error) {
+	err = pg.parser.BuildStatement(stmt, f)
+	if err != nil {
+		return
+	}
+	switch lck {
+	case options.NoLock:
+	case options.LockForRead, options.LockForShareSkipLocked:
+		stmt.WriteString(" FOR SHARE")
+		pg.buildLockOf(stmt, of)
+		if lck == options.LockForShareSkipLocked {
+			stmt.WriteString(" SKIP LOCKED")
+		}
+	default:
+		stmt.WriteString(" FOR UPDATE")
+		pg.buildLockOf(stmt, of)
+		switch lck {
+		case options.LockForUpdateSkipLocked:
+			stmt.WriteString(" SKIP LOCKED")
+		case options.LockForUpdateNoWait:
+			stmt.WriteString(" NOWAIT")
+		}
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// ValidateLock : `SKIP LOCKED` is only supported since postgres 9.5
+func (pg Postgres) ValidateLock(version *semver.Version, lck options.LockMode, of []string) error {
+	if version == nil {
+		return nil
+	}
+	switch lck {
+	case options.LockForUpdateSkipLocked, options.LockForShareSkipLocked:
+		if version.LessThan(postgres95) {
+			return fmt.Errorf("postgres: lock %q is not supported on version %s, it requires %s or above", lck.String(), version, postgres95)
+		}
+	}
+	return nil
+}
+
+func (pg Postgres) buildLockOf(stmt sqlstmt.Stmt, of []string) {
+	if len(of) < 1 {
+		return
+	}
+	tables := make([]string, len(of))
+	for i, table := range of {
+		tables[i] = pg.Quote(table)
+	}
+	stmt.WriteString(" OF " + strings.Join(tables, ","))
+}
+
+// SelectStmt :
+func (pg *Postgres) SelectStmt(stmt sqlstmt.Stmt, query interface{}) (err error) {
+	err = pg.parser.BuildStatement(stmt, query)
+	stmt.WriteByte(';')
+	return
+}
+
+func buildStatement(stmt sqlstmt.Stmt, parser *sqlstmt.StatementBuilder, f interface{}) error {
+	if err := parser.BuildStatement(stmt, f); err != nil {
+		return err
+	}
+	stmt.WriteByte(';')
+	return nil
+}
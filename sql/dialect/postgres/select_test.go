@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/expr"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	var (
+		now = time.Date(2020, 1, 3, 12, 0, 40, 0, time.UTC)
+		err error
+	)
+
+	filters := []interface{}{
+		expr.Equal("A", 1),
+		expr.Like("B", "abc%"),
+		expr.Between("DateTime", now, now.Add(5*time.Minute)),
+	}
+
+	{
+		pg := New()
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = pg.Select(
+			stmt,
+			actions.Find().From("A", "Test").
+				Where(
+					expr.And(filters...),
+					expr.Equal("E", uint(888)),
+				).
+				OrderBy(expr.Desc("A")).
+				Limit(10).(*actions.FindActions), options.LockForUpdate,
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT * FROM "A"."Test" WHERE (("A" = $1 AND "B" LIKE $2 AND "DateTime" BETWEEN $3 AND $4) AND "E" = $5) ORDER BY "A" DESC LIMIT 10 FOR UPDATE;`, stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(1), "abc%", now, now.Add(5 * time.Minute), uint64(888)}, stmt.Args())
+	}
+
+	{
+		pg := New()
+		stmt := sqlstmt.NewStatement(pg)
+		err = pg.Select(
+			stmt,
+			actions.Find().From("A", "Job").
+				InnerJoin(expr.As("User", "u")).
+				On(expr.Equal(expr.Column("u", "ID"), expr.Column("Job", "UserID"))).
+				Limit(1).(*actions.FindActions), options.LockForUpdateSkipLocked, "Job",
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT * FROM "A"."Job" INNER JOIN "A"."User" AS "u" ON "u"."ID" = "Job"."UserID" LIMIT 1 FOR UPDATE OF "Job" SKIP LOCKED;`, stmt.String())
+		require.Error(t, pg.ValidateLock(semver.MustParse("9.4.0"), options.LockForUpdateSkipLocked, nil))
+		require.NoError(t, pg.ValidateLock(semver.MustParse("9.4.0"), options.LockForUpdateNoWait, []string{"Job"}))
+	}
+
+	{
+		dl := New()
+		stmt := sqlstmt.AcquireStmt(dl)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = dl.Select(
+			stmt,
+			actions.Find().
+				Select(expr.Qualified("User", "ID"), expr.Qualified("o", "Amount")).
+				From("db", "User").
+				LeftJoin(expr.As("Order", "o")).
+				On(expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID"))).
+				Where(expr.GreaterThan(expr.Column("o", "Amount"), 10)).(*actions.FindActions), 0,
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT ("User"."ID") AS "User.ID",("o"."Amount") AS "o.Amount" FROM "db"."User" LEFT JOIN "db"."Order" AS "o" ON "o"."UserID" = "User"."ID" WHERE "o"."Amount" > $1;`, stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(10)}, stmt.Args())
+	}
+
+	{
+		pg := New()
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = pg.SelectStmt(stmt, sql.With("active", sql.Select("ID").From("db", "User").Where(expr.Equal("Status", "ACTIVE")), "UserID").
+			Select(expr.Column("o", "ID")).
+			From(expr.As(expr.Column("db", "Order"), "o")).
+			InnerJoin("active").
+			On(expr.Equal(expr.Column("active", "UserID"), expr.Column("o", "UserID"))).
+			Where(expr.GreaterThan(expr.Column("o", "Amount"), 100)),
+		)
+		require.NoError(t, err)
+		require.Equal(t, `WITH "active" ("UserID") AS (SELECT "ID" FROM "db"."User" WHERE "Status" = $1) SELECT "o"."ID" FROM "db"."Order" AS "o" INNER JOIN "active" ON "active"."UserID" = "o"."UserID" WHERE "o"."Amount" > $2;`, stmt.String())
+		require.ElementsMatch(t, []interface{}{"ACTIVE", int64(100)}, stmt.Args())
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	act := actions.Update().
+		Where(expr.Equal("A", "x")).
+		Set(expr.ColumnValue("B", 10)).
+		Limit(1).(*actions.UpdateActions)
+	act.Database = "db"
+	act.Table = "table"
+	err := pg.Update(stmt, act)
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "db"."table" SET "B" = $1 WHERE ctid = ANY(ARRAY(SELECT ctid FROM "db"."table" WHERE "A" = $2 LIMIT 1));`, stmt.String())
+	require.ElementsMatch(t, []interface{}{int64(10), "x"}, stmt.Args())
+}
+
+func TestDelete(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	act := actions.Delete().
+		Where(expr.Equal("A", "x")).(*actions.DeleteActions)
+	act.Database = "db"
+	act.Table = "table"
+	err := pg.Delete(stmt, act)
+	require.NoError(t, err)
+	require.Equal(t, `DELETE FROM "db"."table" WHERE "A" = $1;`, stmt.String())
+	require.ElementsMatch(t, []interface{}{"x"}, stmt.Args())
+}
+
+func TestUpdateAndDeleteWithJoin(t *testing.T) {
+	pg := New()
+
+	{
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		act := actions.Update().
+			InnerJoin(expr.As("Order", "o")).
+			On(expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID"))).
+			Set(expr.ColumnValue("User.Status", "VIP")).
+			Where(expr.GreaterThan(expr.Column("o", "Amount"), 100)).(*actions.UpdateActions)
+		act.Database = "db"
+		act.Table = "User"
+		err := pg.Update(stmt, act)
+		require.NoError(t, err)
+		require.Equal(t, `UPDATE "db"."User" SET "Status" = $1 FROM "db"."Order" AS "o" WHERE ("o"."UserID" = "User"."ID" AND "o"."Amount" > $2);`, stmt.String())
+		require.Equal(t, []interface{}{"VIP", int64(100)}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		act := actions.Delete().
+			InnerJoin(expr.As("Order", "o")).
+			On(expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID"))).
+			Where(expr.Equal(expr.Column("o", "Status"), "FRAUD")).(*actions.DeleteActions)
+		act.Database = "db"
+		act.Table = "User"
+		err := pg.Delete(stmt, act)
+		require.NoError(t, err)
+		require.Equal(t, `DELETE FROM "db"."User" USING "db"."Order" AS "o" WHERE ("o"."UserID" = "User"."ID" AND "o"."Status" = $1);`, stmt.String())
+		require.Equal(t, []interface{}{"FRAUD"}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(pg)
+		defer sqlstmt.ReleaseStmt(stmt)
+		act := actions.Delete().
+			LeftJoin("Order").
+			On(expr.Equal(expr.Column("Order", "UserID"), expr.Column("User", "ID"))).(*actions.DeleteActions)
+		act.Database = "db"
+		act.Table = "User"
+		err := pg.Delete(stmt, act)
+		require.Error(t, err)
+	}
+}
+
+func TestSelectJSONPredicates(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+	err := pg.Select(
+		stmt,
+		actions.Find().
+			From("db", "User").
+			Where(
+				expr.JSONContains("Roles", []string{"admin"}),
+			).(*actions.FindActions), 0,
+	)
+	require.NoError(t, err)
+	require.Equal(t, `SELECT * FROM "db"."User" WHERE ("Roles" @> CAST($1 AS JSONB));`, stmt.String())
+	require.Equal(t, []interface{}{`["admin"]`}, stmt.Args())
+
+	stmt.Reset()
+	err = pg.Select(
+		stmt,
+		actions.Find().
+			From("db", "User").
+			Where(expr.JSONContains("Roles", make(chan int))).(*actions.FindActions), 0,
+	)
+	require.Error(t, err)
+
+	stmt.Reset()
+	err = pg.Select(
+		stmt,
+		actions.Find().
+			From("db", "User").
+			Where(expr.JSONOverlaps("Roles", []string{"admin"})).(*actions.FindActions), 0,
+	)
+	require.Error(t, err)
+}
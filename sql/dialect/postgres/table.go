@@ -0,0 +1,321 @@
+package postgres
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql/driver"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/columns"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+	"github.com/si3nloong/sqlike/sqlike/indexes"
+)
+
+// HasPrimaryKey :
+func (pg Postgres) HasPrimaryKey(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("SELECT COUNT(*) FROM information_schema.table_constraints ")
+	stmt.WriteString("WHERE table_schema = $1 AND table_name = $2 AND constraint_type = 'PRIMARY KEY'")
+	stmt.WriteByte(';')
+	stmt.AppendArgs(db, table)
+}
+
+// RenameTable :
+func (pg Postgres) RenameTable(stmt sqlstmt.Stmt, db, oldName, newName string) {
+	stmt.WriteString("ALTER TABLE ")
+	stmt.WriteString(pg.TableName(db, oldName))
+	stmt.WriteString(" RENAME TO ")
+	stmt.WriteString(pg.Quote(newName))
+	stmt.WriteByte(';')
+}
+
+// DropTable :
+func (pg Postgres) DropTable(stmt sqlstmt.Stmt, db, table string, exists bool) {
+	stmt.WriteString("DROP TABLE")
+	if exists {
+		stmt.WriteString(" IF EXISTS")
+	}
+	stmt.WriteByte(' ')
+	stmt.WriteString(pg.TableName(db, table) + ";")
+}
+
+// TruncateTable :
+func (pg Postgres) TruncateTable(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("TRUNCATE TABLE " + pg.TableName(db, table) + ";")
+}
+
+// HasTable :
+func (pg Postgres) HasTable(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = $1 AND table_name = $2;`)
+	stmt.AppendArgs(dbName, table)
+}
+
+// CreateTable :
+func (pg Postgres) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver.Info, fields []reflext.StructFielder) (err error) {
+	var (
+		col         columns.Column
+		pkk         reflext.StructFielder
+		k1, k2      string
+		virtual     bool
+		stored      bool
+		constraints []string
+		comments    []string
+	)
+
+	fks, err := foreignkeys.FromFields(fields)
+	if err != nil {
+		return err
+	}
+
+	stmt.WriteString("CREATE TABLE " + pg.TableName(db, table) + " ")
+	stmt.WriteByte('(')
+
+	// Main columns :
+	for i, sf := range fields {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+
+		col, err = pg.schema.GetColumn(info, sf)
+		if err != nil {
+			return
+		}
+
+		tag := sf.Tag()
+		// allow primary_key tag to override
+		if _, ok := tag.LookUp("primary_key"); ok {
+			pkk = sf
+		} else if _, ok := tag.LookUp("auto_increment"); ok {
+			pkk = sf
+		} else if sf.Name() == pk && pkk == nil {
+			pkk = sf
+		}
+
+		if _, ok := tag.LookUp("unique_index"); ok {
+			idx := indexes.Index{Type: indexes.Unique, Columns: indexes.Columns(sf.Name())}
+			constraints = append(constraints, "CONSTRAINT "+pg.Quote(pg.indexName(table, idx))+" UNIQUE ("+pg.Quote(sf.Name())+")")
+		}
+
+		pg.buildSchemaByColumn(stmt, col)
+
+		if v, ok := tag.LookUp("comment"); ok {
+			if len(v) > 60 {
+				panic("maximum length of comment is 60 characters")
+			}
+			comments = append(comments, pg.columnComment(db, table, sf.Name(), v))
+		}
+
+		// check generated columns
+		t := reflext.Deref(sf.Type())
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			tg := child.Tag()
+			k1, virtual = tg.LookUp("virtual_column")
+			k2, stored = tg.LookUp("stored_column")
+			if virtual || stored {
+				stmt.WriteByte(',')
+				col, err = pg.schema.GetColumn(info, child)
+				if err != nil {
+					return
+				}
+
+				name := col.Name
+				if virtual && k1 != "" {
+					name = k1
+				}
+				if stored && k2 != "" {
+					name = k2
+				}
+
+				pg.buildGeneratedColumn(stmt, sf, child, name, col)
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+
+	}
+	for _, c := range constraints {
+		stmt.WriteByte(',')
+		stmt.WriteString(c)
+	}
+	if pkk != nil {
+		stmt.WriteByte(',')
+		stmt.WriteString("PRIMARY KEY (" + pg.Quote(pkk.Name()) + ")")
+	}
+	for _, fk := range fks {
+		stmt.WriteByte(',')
+		pg.buildForeignKey(stmt, db, table, fk)
+	}
+	stmt.WriteByte(')')
+	stmt.WriteByte(';')
+	for _, c := range comments {
+		stmt.WriteString(c)
+	}
+	return
+}
+
+// AlterTable : it returns the clauses which drop data, eg. `DROP COLUMN` or `DROP CONSTRAINT`
+func (pg *Postgres) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, cols util.StringSlice, idxs util.StringSlice, fkNames util.StringSlice, unsafe bool) (drops []string, err error) {
+	var (
+		col      columns.Column
+		pkk      reflext.StructFielder
+		idx      int
+		k1, k2   string
+		virtual  bool
+		stored   bool
+		comments []string
+	)
+
+	fks, err := foreignkeys.FromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	adds, dropFks := foreignkeys.Diff(table, fks, fkNames, unsafe)
+
+	stmt.WriteString("ALTER TABLE " + pg.TableName(db, table) + " ")
+	// drop the foreign keys first, so the referencing columns are able to be altered
+	for _, name := range dropFks {
+		clause := "DROP CONSTRAINT " + pg.Quote(name)
+		stmt.WriteString(clause + ",")
+		drops = append(drops, clause)
+	}
+
+	for i, sf := range fields {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+
+		exists := false
+		idx = cols.IndexOf(sf.Name())
+		if idx > -1 {
+			exists = true
+			cols.Splice(idx)
+		}
+		if !hasPk {
+			// allow primary_key tag to override
+			if _, ok := sf.Tag().LookUp("primary_key"); ok {
+				pkk = sf
+			}
+			if sf.Name() == pk && pkk == nil {
+				pkk = sf
+			}
+		}
+
+		tag := sf.Tag()
+		_, ok1 := tag.LookUp("unique_index")
+		_, ok2 := tag.LookUp("auto_increment")
+		if ok1 || ok2 {
+			idx := indexes.Index{Type: indexes.Unique, Columns: indexes.Columns(sf.Name())}
+			name := pg.indexName(table, idx)
+			if idxs.IndexOf(name) < 0 {
+				stmt.WriteString("ADD CONSTRAINT " + pg.Quote(name) + " UNIQUE (" + pg.Quote(sf.Name()) + ")")
+				stmt.WriteByte(',')
+			}
+		}
+		col, err = pg.schema.GetColumn(info, sf)
+		if err != nil {
+			return
+		}
+		if exists {
+			pg.buildAlterColumn(stmt, col)
+		} else {
+			stmt.WriteString("ADD COLUMN ")
+			pg.buildSchemaByColumn(stmt, col)
+		}
+
+		if v, ok := sf.Tag().LookUp("comment"); ok {
+			if len(v) > 60 {
+				panic("maximum length of comment is 60 characters")
+			}
+			comments = append(comments, pg.columnComment(db, table, sf.Name(), v))
+		}
+
+		// check generated columns
+		t := reflext.Deref(sf.Type())
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			tg := child.Tag()
+			k1, virtual = tg.LookUp("virtual_column")
+			k2, stored = tg.LookUp("stored_column")
+			if virtual || stored {
+				col, err = pg.schema.GetColumn(info, child)
+				if err != nil {
+					return
+				}
+
+				name := col.Name
+				if virtual && k1 != "" {
+					name = k1
+				}
+				if stored && k2 != "" {
+					name = k2
+				}
+
+				// postgres doesn't allow to alter the expression of generated column
+				idx = cols.IndexOf(name)
+				if idx > -1 {
+					cols.Splice(idx)
+				} else {
+					stmt.WriteString(",ADD COLUMN ")
+					pg.buildGeneratedColumn(stmt, sf, child, name, col)
+				}
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+
+	}
+
+	if pkk != nil {
+		stmt.WriteByte(',')
+		stmt.WriteString("ADD PRIMARY KEY (" + pg.Quote(pkk.Name()) + ")")
+	}
+
+	for _, fk := range adds {
+		stmt.WriteString(",ADD ")
+		pg.buildForeignKey(stmt, db, table, fk)
+	}
+
+	if unsafe {
+		for _, col := range cols {
+			clause := "DROP COLUMN " + pg.Quote(col)
+			stmt.WriteByte(',')
+			stmt.WriteString(clause)
+			drops = append(drops, clause)
+		}
+	}
+
+	stmt.WriteByte(';')
+	for _, c := range comments {
+		stmt.WriteString(c)
+	}
+	return
+}
+
+// buildGeneratedColumn : postgres only support stored generated column, virtual column will be stored as well
+func (pg Postgres) buildGeneratedColumn(stmt sqlstmt.Stmt, parent, child reflext.StructFielder, name string, col columns.Column) {
+	path := strings.TrimLeft(strings.TrimPrefix(child.Name(), parent.Name()), ".")
+	stmt.WriteString(pg.Quote(name))
+	stmt.WriteString(" " + col.Type)
+	stmt.WriteString(" GENERATED ALWAYS AS ")
+	stmt.WriteString("((" + pg.Quote(parent.Name()) + "#>>'{" + strings.ReplaceAll(path, ".", ",") + "}')::" + col.Type + ")")
+	stmt.WriteString(" STORED")
+	if !col.Nullable {
+		stmt.WriteString(" NOT NULL")
+	}
+}
+
+func (pg Postgres) columnComment(db, table, column, comment string) string {
+	return "COMMENT ON COLUMN " + pg.TableName(db, table) + "." + pg.Quote(column) + " IS " + pg.Wrap(comment) + ";"
+}
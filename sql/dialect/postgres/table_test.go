@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"testing"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasPrimaryKey(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.HasPrimaryKey(stmt, "db", "table")
+	require.Equal(t, "SELECT COUNT(*) FROM information_schema.table_constraints WHERE table_schema = $1 AND table_name = $2 AND constraint_type = 'PRIMARY KEY';", stmt.String())
+	require.ElementsMatch(t, []interface{}{"db", "table"}, stmt.Args())
+}
+
+func TestDropTable(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	{
+		pg.DropTable(stmt, "db", "table", true)
+		require.Equal(t, `DROP TABLE IF EXISTS "db"."table";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+
+	stmt.Reset()
+
+	{
+		pg.DropTable(stmt, "db", "table", false)
+		require.Equal(t, `DROP TABLE "db"."table";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+}
+
+func TestRenameTable(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+	pg.RenameTable(stmt, "db", "oldName", "newName")
+	require.Equal(t, `ALTER TABLE "db"."oldName" RENAME TO "newName";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestHasTable(t *testing.T) {
+	pg := New()
+	stmt := sqlstmt.AcquireStmt(pg)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	pg.HasTable(stmt, "db", "table")
+	require.Equal(t, "SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = $1 AND table_name = $2;", stmt.String())
+	require.ElementsMatch(t, []interface{}{"db", "table"}, stmt.Args())
+}
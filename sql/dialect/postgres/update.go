@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+)
+
+// Update :
+func (pg *Postgres) Update(stmt sqlstmt.Stmt, f *actions.UpdateActions) (err error) {
+	err = buildStatement(stmt, pg.parser, f)
+	if err != nil {
+		return
+	}
+	return
+}
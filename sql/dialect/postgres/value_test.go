@@ -0,0 +1,40 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	var (
+		pg  = New()
+		str string
+	)
+
+	str = pg.Format(int64(-638731231286))
+	require.Equal(t, "-638731231286", str)
+
+	str = pg.Format(uint64(638731231286))
+	require.Equal(t, "638731231286", str)
+
+	str = pg.Format("it's a string")
+	require.Equal(t, `'it''s a string'`, str)
+
+	str = pg.Format(true)
+	require.Equal(t, "TRUE", str)
+
+	str = pg.Format(false)
+	require.Equal(t, "FALSE", str)
+
+	str = pg.Format(nil)
+	require.Equal(t, "NULL", str)
+
+	ts, _ := time.Parse("2006-01-02 15:04:05", "2020-01-03 12:00:40")
+	str = pg.Format(ts)
+	require.Equal(t, `'2020-01-03 12:00:40'`, str)
+
+	str = pg.Format([]byte("hello world"))
+	require.Equal(t, `'hello world'`, str)
+}
@@ -0,0 +1,228 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/spatial"
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/dialect/internal/base"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
+)
+
+// sqliteBuilder : only the builders which the syntax is different are overridden, the rest are shared in `base.Builder`
+type sqliteBuilder struct {
+	*base.Builder
+	sqlutil.SQLiteUtil
+}
+
+func (b sqliteBuilder) SetRegistryAndBuilders(rg codec.Codecer, blr *sqlstmt.StatementBuilder) {
+	b.Builder = base.New("sqlite", &b, rg, blr)
+	b.SetBuilders(blr)
+	blr.SetBuilder(reflect.TypeOf(primitive.CastAs{}), b.BuildCastAs)
+	blr.SetBuilder(reflect.TypeOf(primitive.JSONFunc{}), b.BuildJSONFunction)
+	blr.SetBuilder(reflect.TypeOf(primitive.Field{}), b.BuildField)
+	blr.SetBuilder(reflect.TypeOf(primitive.JSONColumn{}), b.BuildJSONColumn)
+	blr.SetBuilder(reflect.TypeOf(primitive.Inserted{}), b.BuildInserted)
+	blr.SetBuilder(reflect.TypeOf(primitive.L{}), b.BuildLike)
+	blr.SetBuilder(reflect.TypeOf(spatial.Func{}), b.BuildSpatialFunc)
+	blr.SetBuilder(reflect.TypeOf(&actions.DeleteActions{}), b.BuildDeleteActions)
+}
+
+// WriteValue :
+func (b *sqliteBuilder) WriteValue(stmt sqlstmt.Stmt, val interface{}) {
+	convertSpatial(stmt, val)
+}
+
+// BuildLike : sqlite doesn't have default escape character
+func (b *sqliteBuilder) BuildLike(stmt sqlstmt.Stmt, it interface{}) error {
+	if err := b.Builder.BuildLike(stmt, it); err != nil {
+		return err
+	}
+	v := reflext.ValueOf(it.(primitive.L).Value)
+	if !v.IsValid() {
+		return nil
+	}
+	if _, ok := b.Parser.LookupBuilder(v.Type()); !ok {
+		stmt.WriteString(` ESCAPE '\'`)
+	}
+	return nil
+}
+
+// BuildDeleteActions : sqlite doesn't support multiple-table delete, use `expr.Exists` with subquery instead
+func (b *sqliteBuilder) BuildDeleteActions(stmt sqlstmt.Stmt, it interface{}) error {
+	if len(it.(*actions.DeleteActions).Joins) > 0 {
+		return errors.New("sqlite: multiple-table delete is not supported")
+	}
+	return b.Builder.BuildDeleteActions(stmt, it)
+}
+
+// BuildCastAs :
+func (b *sqliteBuilder) BuildCastAs(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.CastAs)
+	stmt.WriteString("CAST(")
+	if err := b.Parser.BuildStatement(stmt, x.Value); err != nil {
+		return err
+	}
+	stmt.WriteString(" AS ")
+	switch x.DataType {
+	// sqlite store json as text
+	case primitive.JSON, primitive.Varchar, primitive.Char:
+		stmt.WriteString("TEXT")
+	case primitive.Date:
+		stmt.WriteString("DATE")
+	default:
+		return errors.New("sqlite: unsupported cast as data type")
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildJSONFunction : most of the `JSON_*` functions are available in sqlite json1 extension,
+// the rest will be translated into equivalent expression.
+func (b *sqliteBuilder) BuildJSONFunction(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.JSONFunc)
+	switch x.Type {
+	case primitive.JSON_CONTAINS, primitive.JSON_OVERLAPS, primitive.JSON_SEARCH, primitive.JSON_LENGTH, primitive.JSON_CONTAINS_PATH:
+		return fmt.Errorf("sqlite: unsupported json function %s", x.Type)
+	case primitive.MEMBER_OF:
+		stmt.WriteString("EXISTS(SELECT 1 FROM JSON_EACH(")
+		if err := b.Parser.BuildStatement(stmt, x.Args[0]); err != nil {
+			return err
+		}
+		stmt.WriteString(") WHERE value = ")
+		if err := b.GetValue(stmt, x.Prefix); err != nil {
+			return err
+		}
+		stmt.WriteByte(')')
+		return nil
+	case primitive.JSON_KEYS:
+		stmt.WriteString("(SELECT JSON_GROUP_ARRAY(key) FROM JSON_EACH(")
+		if err := b.appendArgs(stmt, x.Args); err != nil {
+			return err
+		}
+		stmt.WriteString("))")
+		return nil
+	case primitive.JSON_TYPE:
+		// sqlite return the type in lower case
+		stmt.WriteString("UPPER(JSON_TYPE(")
+		if err := b.appendArgs(stmt, x.Args); err != nil {
+			return err
+		}
+		stmt.WriteString("))")
+		return nil
+	case primitive.JSON_UNQUOTE:
+		stmt.WriteByte('(')
+		if err := b.appendArgs(stmt, x.Args); err != nil {
+			return err
+		}
+		stmt.WriteString(" ->> '$')")
+		return nil
+	}
+	stmt.WriteString(x.Type.String())
+	stmt.WriteByte('(')
+	if err := b.appendArgs(stmt, x.Args); err != nil {
+		return err
+	}
+	stmt.WriteByte(')')
+	return nil
+}
+
+// BuildField : sqlite doesn't support `FIELD`, we use `CASE` instead
+func (b *sqliteBuilder) BuildField(stmt sqlstmt.Stmt, it interface{}) error {
+	x := it.(primitive.Field)
+	stmt.WriteString("CASE " + b.Quote(x.Name))
+	for i, v := range x.Values {
+		stmt.WriteString(" WHEN ")
+		if err := b.GetValue(stmt, v); err != nil {
+			return err
+		}
+		stmt.WriteString(" THEN " + strconv.Itoa(i+1))
+	}
+	stmt.WriteString(" ELSE 0 END")
+	return nil
+}
+
+// BuildJSONColumn :
+func (b *sqliteBuilder) BuildJSONColumn(stmt sqlstmt.Stmt, it interface{}) error {
+	/*
+		Expected columns ( JSON_EXTRACT )
+		Column : Address
+		Nested : [ State, City ]
+		UnquoteResult : false
+
+		Result
+		"Address"->'$.State.City'
+
+		--------------------------------------------
+
+		Expected columns ( JSON_EXTRACT(JSON_UNQUOTE) )
+		Column : Address
+		Nested : [ State, City ]
+		UnquoteResult : true
+
+		Result
+		"Address"->>'$.State.City'
+	*/
+	x := it.(primitive.JSONColumn)
+	nested := strings.Join(x.Nested, ".")
+	operator := "->"
+	if !strings.HasPrefix(nested, "$.") {
+		nested = "$." + nested
+	}
+	if x.UnquoteResult {
+		operator += ">"
+	}
+	stmt.WriteString(b.Quote(x.Column) + operator + b.Wrap(nested))
+	return nil
+}
+
+// BuildInserted :
+func (b *sqliteBuilder) BuildInserted(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(primitive.Inserted)
+	stmt.WriteString("excluded." + b.Quote(x.Field))
+	return
+}
+
+// BuildSpatialFunc : sqlite doesn't have spatial functions unless `spatialite` extension is loaded
+func (b *sqliteBuilder) BuildSpatialFunc(stmt sqlstmt.Stmt, it interface{}) (err error) {
+	x := it.(spatial.Func)
+	return fmt.Errorf("sqlite: unsupported spatial function %s", x.Type)
+}
+
+func (b *sqliteBuilder) appendArgs(stmt sqlstmt.Stmt, args []interface{}) error {
+	for i, arg := range args {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		if err := b.Parser.BuildStatement(stmt, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendLimitedWhere : sqlite doesn't support `ORDER BY` and `LIMIT` on `UPDATE` and `DELETE` by default,
+// so we limit the affected rows using the `rowid` instead.
+func (b *sqliteBuilder) AppendLimitedWhere(stmt sqlstmt.Stmt, db, table string, conds []interface{}, sorts []interface{}, limit uint) error {
+	if limit < 1 && len(sorts) < 1 {
+		return b.AppendWhere(stmt, conds)
+	}
+	stmt.WriteString(" WHERE rowid IN (SELECT rowid FROM " + b.TableName(db, table))
+	if err := b.AppendWhere(stmt, conds); err != nil {
+		return err
+	}
+	if err := b.AppendOrderBy(stmt, sorts); err != nil {
+		return err
+	}
+	b.AppendLimitNOffset(stmt, limit, 0)
+	stmt.WriteByte(')')
+	return nil
+}
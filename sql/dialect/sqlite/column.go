@@ -0,0 +1,26 @@
+package sqlite
+
+import sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+
+// GetColumns :
+func (s *SQLite) GetColumns(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT cid + 1, name, UPPER(type), dflt_value, CASE WHEN "notnull" = 0 THEN 'YES' ELSE 'NO' END,
+	UPPER(CASE WHEN INSTR(type, '(') > 0 THEN SUBSTR(type, 1, INSTR(type, '(') - 1) ELSE type END), NULL, NULL, '',
+	CASE WHEN hidden = 2 THEN 'VIRTUAL GENERATED' WHEN hidden = 3 THEN 'STORED GENERATED' ELSE '' END
+	FROM pragma_table_xinfo(?, ?) ORDER BY cid;`)
+	stmt.AppendArgs(table, dbName)
+}
+
+// RenameColumn :
+func (s *SQLite) RenameColumn(stmt sqlstmt.Stmt, db, table, oldColName, newColName string) {
+	stmt.WriteString("ALTER TABLE " + s.TableName(db, table))
+	stmt.WriteString(" RENAME COLUMN " + s.Quote(oldColName) + " TO " + s.Quote(newColName))
+	stmt.WriteByte(';')
+}
+
+// DropColumn :
+func (s *SQLite) DropColumn(stmt sqlstmt.Stmt, db, table, column string) {
+	stmt.WriteString("ALTER TABLE " + s.TableName(db, table))
+	stmt.WriteString(" DROP COLUMN " + s.Quote(column))
+	stmt.WriteByte(';')
+}
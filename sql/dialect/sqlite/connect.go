@@ -0,0 +1,19 @@
+package sqlite
+
+import (
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+// Connect : sqlite connection string is the database file path (or uri), which can be provided
+// by `ApplyURI` or `SetHost`, eg. `file:test.db?cache=shared` or `:memory:`
+func (s *SQLite) Connect(opt *options.ConnectOptions) (connStr string) {
+	if opt.RawConnStr() != "" {
+		connStr = opt.RawConnStr()
+		return
+	}
+	if opt.Host == "" {
+		panic("missing database file for sqlite connection")
+	}
+	connStr = opt.Host
+	return
+}
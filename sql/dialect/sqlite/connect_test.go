@@ -0,0 +1,29 @@
+package sqlite
+
+import (
+	"testing"
+
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+// TestConnect :
+func TestConnect(t *testing.T) {
+	var (
+		s   = SQLite{}
+		str string
+	)
+
+	str = s.Connect(options.Connect().SetHost("test.db"))
+	require.Equal(t, `test.db`, str)
+
+	uri := `file::memory:?cache=shared`
+	opt := new(options.ConnectOptions)
+	str = s.Connect(opt.ApplyURI(uri))
+	require.Equal(t, uri, str)
+
+	require.Panics(t, func() {
+		opt := new(options.ConnectOptions)
+		s.Connect(opt)
+	})
+}
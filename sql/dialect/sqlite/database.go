@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+)
+
+// UseDatabase : sqlite doesn't have `USE`, every statement is qualified with the schema name,
+// so we only verify the schema is accessible.
+func (s *SQLite) UseDatabase(stmt sqlstmt.Stmt, db string) {
+	stmt.WriteString("PRAGMA " + s.Quote(db) + ".schema_version;")
+}
+
+// CreateDatabase : attach a database file named `<db>.db` with the schema name.
+// Beware, attached database only available on the current connection.
+func (s *SQLite) CreateDatabase(stmt sqlstmt.Stmt, db string, checkExists bool) {
+	stmt.WriteString("ATTACH DATABASE " + s.Wrap(db+".db") + " AS " + s.Quote(db) + ";")
+}
+
+// DropDatabase : detach the attached database, the database file will remain.
+func (s *SQLite) DropDatabase(stmt sqlstmt.Stmt, db string, checkExists bool) {
+	stmt.WriteString("DETACH DATABASE " + s.Quote(db) + ";")
+}
+
+// GetDatabases :
+func (s *SQLite) GetDatabases(stmt sqlstmt.Stmt) {
+	stmt.WriteString("SELECT name FROM pragma_database_list;")
+}
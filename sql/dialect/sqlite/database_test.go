@@ -0,0 +1,44 @@
+package sqlite
+
+import (
+	"testing"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseDatabase(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.UseDatabase(stmt, "main")
+	require.Equal(t, `PRAGMA "main".schema_version;`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestCreateDatabase(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.CreateDatabase(stmt, "db", true)
+	require.Equal(t, `ATTACH DATABASE 'db.db' AS "db";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestDropDatabase(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.DropDatabase(stmt, "db", true)
+	require.Equal(t, `DETACH DATABASE "db";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestGetDatabases(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.GetDatabases(stmt)
+	require.Equal(t, "SELECT name FROM pragma_database_list;", stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
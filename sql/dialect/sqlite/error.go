@@ -0,0 +1,55 @@
+package sqlite
+
+import (
+	"errors"
+	"strings"
+
+	sqlerrors "github.com/si3nloong/sqlike/sqlike/errors"
+)
+
+// IsRetryable : whether the database is locked by another connection (`SQLITE_BUSY` or `SQLITE_LOCKED`),
+// the error type differs between drivers, so the message is checked instead
+func (s *SQLite) IsRetryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "database table is locked")
+}
+
+// MapError : map the sqlite error to portable error by message, the error is returned as it is if it's not mapped.
+// The index name is not reported by sqlite, so the offending columns are used instead, eg. `users.Email`
+func (s *SQLite) MapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var pe *sqlerrors.Error
+	if errors.As(err, &pe) {
+		return err
+	}
+	msg := err.Error()
+	switch {
+	case strings.Contains(msg, "UNIQUE constraint failed: "):
+		return sqlerrors.New(sqlerrors.ErrDuplicateKey, err).WithIndex(after(msg, "UNIQUE constraint failed: "))
+	case strings.Contains(msg, "PRIMARY KEY constraint failed: "):
+		return sqlerrors.New(sqlerrors.ErrDuplicateKey, err).WithIndex(after(msg, "PRIMARY KEY constraint failed: "))
+	case strings.Contains(msg, "FOREIGN KEY constraint failed"):
+		return sqlerrors.New(sqlerrors.ErrForeignKeyViolation, err)
+	case strings.Contains(msg, "no such table: "):
+		return sqlerrors.New(sqlerrors.ErrTableNotFound, err)
+	case s.IsRetryable(err):
+		return sqlerrors.New(sqlerrors.ErrLockWaitTimeout, err)
+	}
+	return err
+}
+
+// after : the rest of message after the prefix, until the end of line or the error code, eg. `UNIQUE constraint failed: users.Email (2067)`
+func after(msg, prefix string) string {
+	msg = msg[strings.Index(msg, prefix)+len(prefix):]
+	for _, end := range []string{" (", "\n"} {
+		if i := strings.Index(msg, end); i >= 0 {
+			msg = msg[:i]
+		}
+	}
+	return strings.TrimSpace(msg)
+}
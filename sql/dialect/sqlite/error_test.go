@@ -0,0 +1,27 @@
+package sqlite
+
+import (
+	"errors"
+	"testing"
+
+	sqlerrors "github.com/si3nloong/sqlike/sqlike/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapError(t *testing.T) {
+	s := SQLite{}
+	require.Nil(t, s.MapError(nil))
+
+	err := s.MapError(errors.New("UNIQUE constraint failed: users.A, users.B (2067)"))
+	require.True(t, errors.Is(err, sqlerrors.ErrDuplicateKey))
+	var e *sqlerrors.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, "users.A, users.B", e.Index)
+
+	require.True(t, errors.Is(s.MapError(errors.New("FOREIGN KEY constraint failed")), sqlerrors.ErrForeignKeyViolation))
+	require.True(t, errors.Is(s.MapError(errors.New("no such table: users")), sqlerrors.ErrTableNotFound))
+	require.True(t, errors.Is(s.MapError(errors.New("database is locked (5) (SQLITE_BUSY)")), sqlerrors.ErrLockWaitTimeout))
+
+	other := errors.New("near \"SELEC\": syntax error")
+	require.Equal(t, other, s.MapError(other))
+}
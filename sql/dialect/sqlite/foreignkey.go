@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"errors"
+	"strings"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+)
+
+// ErrAlterForeignKey : sqlite only able to declare foreign key on `CREATE TABLE`
+var ErrAlterForeignKey = errors.New("sqlite: foreign key can only be declared on create table")
+
+// GetForeignKeys : sqlite doesn't keep the constraint name, so the name will be the id of the foreign key
+func (s *SQLite) GetForeignKeys(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString(`SELECT CAST(id AS TEXT), "from", "table", "to", on_delete, on_update FROM pragma_foreign_key_list(?, ?) ORDER BY id, seq;`)
+	stmt.AppendArgs(table, db)
+}
+
+// CreateForeignKeys : sqlite doesn't support adding foreign key using `ALTER TABLE`
+func (s *SQLite) CreateForeignKeys(stmt sqlstmt.Stmt, db, table string, fks []foreignkeys.ForeignKey) (err error) {
+	return ErrAlterForeignKey
+}
+
+// DropForeignKeys : sqlite doesn't support dropping foreign key using `ALTER TABLE`
+func (s *SQLite) DropForeignKeys(stmt sqlstmt.Stmt, db, table string, names []string) (err error) {
+	return ErrAlterForeignKey
+}
+
+// buildForeignKey : the referenced table must be within the same schema, so it cannot be qualified
+func (s *SQLite) buildForeignKey(stmt sqlstmt.Stmt, table string, fk foreignkeys.ForeignKey) {
+	stmt.WriteString("CONSTRAINT " + s.Quote(fk.GetName(table)) + " FOREIGN KEY (")
+	stmt.WriteString(s.quoteColumns(fk.Columns))
+	stmt.WriteString(") REFERENCES " + s.Quote(fk.RefTable) + " (")
+	stmt.WriteString(s.quoteColumns(fk.RefColumns))
+	stmt.WriteByte(')')
+	if fk.OnDelete != "" {
+		stmt.WriteString(" ON DELETE " + string(fk.OnDelete))
+	}
+	if fk.OnUpdate != "" {
+		stmt.WriteString(" ON UPDATE " + string(fk.OnUpdate))
+	}
+}
+
+func (s *SQLite) quoteColumns(cols []string) string {
+	blr := new(strings.Builder)
+	for i, col := range cols {
+		if i > 0 {
+			blr.WriteByte(',')
+		}
+		blr.WriteString(s.Quote(col))
+	}
+	return blr.String()
+}
@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/si3nloong/sqlike/reflext"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignKeys(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	s.GetForeignKeys(stmt, "main", "posts")
+	require.Equal(t, `SELECT CAST(id AS TEXT), "from", "table", "to", on_delete, on_update FROM pragma_foreign_key_list(?, ?) ORDER BY id, seq;`, stmt.String())
+	require.ElementsMatch(t, []interface{}{"posts", "main"}, stmt.Args())
+
+	type post struct {
+		ID     int64 `sqlike:",primary_key"`
+		UserID int64 `sqlike:",foreign_key=users.ID,on_update=cascade"`
+	}
+
+	stmt.Reset()
+	fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(post{})).Properties()
+	require.NoError(t, s.CreateTable(stmt, "main", "posts", "ID", driverInfo{}, fields))
+	fks, err := foreignkeys.FromFields(fields)
+	require.NoError(t, err)
+	require.Contains(t, stmt.String(), `PRIMARY KEY ("ID"),CONSTRAINT "`+fks[0].GetName("posts")+`" FOREIGN KEY ("UserID") REFERENCES "users" ("ID") ON UPDATE CASCADE);`)
+
+	require.Equal(t, ErrAlterForeignKey, s.CreateForeignKeys(stmt, "main", "posts", fks))
+	require.Equal(t, ErrAlterForeignKey, s.DropForeignKeys(stmt, "main", "posts", []string{"0"}))
+
+	// the declared foreign key already exists
+	stmt.Reset()
+	cols := util.StringSlice{"ID", "UserID"}
+	drops, err := s.AlterTable(stmt, "main", "posts", "ID", true, driverInfo{}, fields, cols, util.StringSlice{}, util.StringSlice{fks[0].GetName("posts")}, true)
+	require.NoError(t, err)
+	require.Empty(t, drops)
+	require.Empty(t, stmt.String())
+
+	// the foreign key cannot be added or dropped on existing table
+	_, err = s.AlterTable(stmt, "main", "posts", "ID", true, driverInfo{}, fields, cols, util.StringSlice{}, util.StringSlice{}, false)
+	require.Equal(t, ErrAlterForeignKey, err)
+	_, err = s.AlterTable(stmt, "main", "posts", "ID", true, driverInfo{}, fields, cols, util.StringSlice{}, util.StringSlice{fks[0].GetName("posts"), "0"}, true)
+	require.Equal(t, ErrAlterForeignKey, err)
+	// the unmanaged foreign key is only dropped on unsafe migration
+	_, err = s.AlterTable(stmt, "main", "posts", "ID", true, driverInfo{}, fields, cols, util.StringSlice{}, util.StringSlice{fks[0].GetName("posts"), "0"}, false)
+	require.NoError(t, err)
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"strings"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/indexes"
+)
+
+// HasIndexByName :
+func (s *SQLite) HasIndexByName(stmt sqlstmt.Stmt, dbName, table, indexName string) {
+	stmt.WriteString(`SELECT COUNT(1) FROM pragma_index_list(?, ?) WHERE name = ?;`)
+	stmt.AppendArgs(table, dbName, indexName)
+}
+
+// HasIndex :
+func (s *SQLite) HasIndex(stmt sqlstmt.Stmt, dbName, table string, idx indexes.Index) {
+	unique := false
+	switch idx.Type {
+	case indexes.Unique, indexes.Primary:
+		unique = true
+	}
+	args := []interface{}{table, dbName, dbName, unique}
+	stmt.WriteString("SELECT COUNT(1) FROM (")
+	stmt.WriteString("SELECT il.name, COUNT(*) AS c FROM pragma_index_list(?, ?) AS il ")
+	stmt.WriteString("JOIN pragma_index_info(il.name, ?) AS ii ")
+	stmt.WriteString(`WHERE il."unique" = ? `)
+	stmt.WriteString("AND ii.name IN ")
+	stmt.WriteByte('(')
+	for i, col := range idx.Columns {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		args = append(args, col.Name)
+		stmt.WriteByte('?')
+	}
+	stmt.WriteByte(')')
+	stmt.WriteString(" GROUP BY il.name")
+	args = append(args, int64(len(idx.Columns)))
+	stmt.WriteString(") AS temp WHERE temp.c = ?")
+	stmt.WriteByte(';')
+	stmt.AppendArgs(args...)
+}
+
+// GetIndexes :
+func (s *SQLite) GetIndexes(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT name, 'BTREE', NOT "unique" FROM pragma_index_list(?, ?);`)
+	stmt.AppendArgs(table, dbName)
+}
+
+// CreateIndexes : sqlite only support b-tree index, so fulltext, spatial and multi-valued index will be created as normal index.
+func (s *SQLite) CreateIndexes(stmt sqlstmt.Stmt, db, table string, idxs []indexes.Index, supportDesc bool) {
+	for _, idx := range idxs {
+		stmt.WriteString("CREATE ")
+		switch idx.Type {
+		case indexes.Unique, indexes.Primary:
+			// sqlite doesn't allow to add primary key on existing table
+			stmt.WriteString("UNIQUE ")
+		}
+		stmt.WriteString("INDEX " + s.TableName(db, s.indexName(table, idx)) + " ON " + s.Quote(table) + " (")
+		if idx.Type == indexes.MultiValued {
+			// sqlite cannot index on the json array element, the whole document is indexed instead
+			if strings.Contains(idx.Cast, "->") {
+				stmt.WriteString("(" + idx.Cast + ")")
+			} else {
+				stmt.WriteString(s.Quote(idx.Cast))
+			}
+		} else {
+			for j, col := range idx.Columns {
+				if j > 0 {
+					stmt.WriteByte(',')
+				}
+				stmt.WriteString(s.Quote(col.Name))
+				if !supportDesc {
+					continue
+				}
+				if col.Direction == indexes.Descending {
+					stmt.WriteString(" DESC")
+				}
+			}
+		}
+		stmt.WriteString(");")
+	}
+}
+
+// DropIndexes :
+func (s *SQLite) DropIndexes(stmt sqlstmt.Stmt, db, table string, idxs []string) {
+	for _, idx := range idxs {
+		stmt.WriteString("DROP INDEX IF EXISTS " + s.TableName(db, idx) + ";")
+	}
+}
+
+// indexName : index name in sqlite is unique across the schema, so the generated name will be prefixed by table name
+func (s *SQLite) indexName(table string, idx indexes.Index) string {
+	if idx.Name != "" {
+		return idx.Name
+	}
+	return table + "_" + idx.HashName()
+}
+
+// UniqueIndexName : name of the unique index which created by `unique_index` tag
+func (s *SQLite) UniqueIndexName(table, column string) string {
+	return s.indexName(table, indexes.Index{Type: indexes.Unique, Columns: indexes.Columns(column)})
+}
+
+// UniqueAutoIncrement : whether `AlterTable` creates an unique index on the `auto_increment` column, sqlite only allow `AUTOINCREMENT` on `INTEGER PRIMARY KEY`
+func (s *SQLite) UniqueAutoIncrement() bool {
+	return false
+}
@@ -0,0 +1,49 @@
+package sqlite
+
+import (
+	"testing"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/indexes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasIndexByName(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.HasIndexByName(stmt, "main", "table", "idx1")
+	require.Equal(t, `SELECT COUNT(1) FROM pragma_index_list(?, ?) WHERE name = ?;`, stmt.String())
+	require.ElementsMatch(t, []interface{}{"table", "main", "idx1"}, stmt.Args())
+}
+
+func TestGetIndexes(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.GetIndexes(stmt, "main", "table")
+	require.Equal(t, `SELECT name, 'BTREE', NOT "unique" FROM pragma_index_list(?, ?);`, stmt.String())
+	require.ElementsMatch(t, []interface{}{"table", "main"}, stmt.Args())
+}
+
+func TestCreateIndexes(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	s.CreateIndexes(stmt, "main", "table", []indexes.Index{
+		{Name: "idx_name", Type: indexes.Unique, Columns: indexes.Columns("Name", "Email")},
+		{Name: "idx_date", Type: indexes.BTree, Columns: []indexes.Col{{Name: "Date", Direction: indexes.Descending}}},
+	}, true)
+	require.Equal(t, `CREATE UNIQUE INDEX "main"."idx_name" ON "table" ("Name","Email");CREATE INDEX "main"."idx_date" ON "table" ("Date" DESC);`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestDropIndexes(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.DropIndexes(stmt, "main", "table", []string{"idx1", "idx2"})
+	require.Equal(t, `DROP INDEX IF EXISTS "main"."idx1";DROP INDEX IF EXISTS "main"."idx2";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
@@ -0,0 +1,254 @@
+package sqlite
+
+import (
+	"reflect"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/spatial"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/driver"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
+)
+
+// ValidateInsert : the inserted row of `ON CONFLICT` is always referred by `EXCLUDED`, so every version is supported
+func (s *SQLite) ValidateInsert(info driver.Info, opt *options.InsertOptions) error {
+	return nil
+}
+
+// InsertInto :
+func (s *SQLite) InsertInto(stmt sqlstmt.Stmt, db, table, pk string, cache reflext.StructMapper, cdc codec.Codecer, fields []reflext.StructFielder, v reflect.Value, opt *options.InsertOptions) (err error) {
+	records := v.Len()
+
+	stmt.WriteString("INSERT ")
+	if opt.Mode == options.InsertIgnore {
+		stmt.WriteString("OR IGNORE ")
+	}
+	stmt.WriteString("INTO " + s.TableName(db, table) + " (")
+
+	omitField := make(map[string]bool)
+	noOfOmit := len(opt.Omits)
+	for i := 0; i < len(fields); {
+		// omit all the field provided by user
+		if noOfOmit > 0 && opt.Omits.IndexOf(fields[i].Name()) > -1 {
+			if opt.Mode != options.InsertOnDuplicate {
+				fields = append(fields[:i], fields[i+1:]...)
+				continue
+			} else {
+				omitField[fields[i].Name()] = true
+			}
+		}
+
+		// omit all the struct field with `generated_column` tag, it shouldn't include when inserting to the db
+		if _, ok := fields[i].Tag().LookUp("generated_column"); ok {
+			fields = append(fields[:i], fields[i+1:]...)
+			continue
+		}
+
+		stmt.WriteString(s.Quote(fields[i].Name()))
+		if i < len(fields)-1 {
+			stmt.WriteByte(',')
+		}
+
+		i++
+	}
+	stmt.WriteString(") VALUES ")
+
+	length := len(fields)
+	encoders := make([]codec.ValueEncoder, length)
+	for i := 0; i < records; i++ {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteByte('(')
+		vi := reflext.Indirect(v.Index(i))
+
+		for j := range fields {
+			if j > 0 {
+				stmt.WriteByte(',')
+			}
+
+			fv := cache.FieldByIndexesReadOnly(vi, fields[j].Index())
+			// auto increment column will generate the next rowid if it's null
+			if _, ok := fields[j].Tag().LookUp("auto_increment"); ok && reflext.IsZero(fv) {
+				stmt.WriteString("NULL")
+				continue
+			}
+
+			if encoders[j] == nil {
+				encoders[j], err = cdc.LookupEncoder(fv)
+				if err != nil {
+					return err
+				}
+			}
+
+			val, err := encoders[j](fields[j], fv)
+			if err != nil {
+				return err
+			}
+
+			convertSpatial(stmt, val)
+		}
+		stmt.WriteByte(')')
+	}
+
+	if opt.Mode == options.InsertOnDuplicate {
+		if err := s.buildOnConflict(stmt, pk, updatableColumns(fields, pk, omitField), opt.OnConflict); err != nil {
+			return err
+		}
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// InsertFrom : `INSERT INTO ... SELECT`, the select statement is wrapped on upsert to avoid the parsing ambiguity of `ON CONFLICT`
+func (s *SQLite) InsertFrom(stmt sqlstmt.Stmt, db, table, pk string, columns []string, query *sql.SelectStmt, opt *options.InsertOptions) (err error) {
+	stmt.WriteString("INSERT")
+	if opt.Mode == options.InsertIgnore {
+		stmt.WriteString(" OR IGNORE")
+	}
+	stmt.WriteString(" INTO " + s.TableName(db, table) + " ")
+	if len(columns) > 0 {
+		stmt.WriteByte('(')
+		for i, col := range columns {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(s.Quote(col))
+		}
+		stmt.WriteString(") ")
+	}
+	if opt.Mode != options.InsertOnDuplicate {
+		if err := s.parser.BuildStatement(stmt, query); err != nil {
+			return err
+		}
+		stmt.WriteByte(';')
+		return
+	}
+
+	stmt.WriteString("SELECT * FROM (")
+	if err := s.parser.BuildStatement(stmt, query); err != nil {
+		return err
+	}
+	stmt.WriteString(") WHERE true")
+	// the inserted columns except primary key and omitted fields will be updated by default
+	updates := make([]string, 0, len(columns))
+	for _, col := range columns {
+		if col == pk || opt.Omits.IndexOf(col) > -1 {
+			continue
+		}
+		updates = append(updates, col)
+	}
+	if err := s.buildOnConflict(stmt, pk, updates, opt.OnConflict); err != nil {
+		return err
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// buildOnConflict : without conflict target, it will be applied on any uniqueness constraint, just like mysql
+func (s *SQLite) buildOnConflict(stmt sqlstmt.Stmt, pk string, columns []string, opt *options.OnConflictOptions) error {
+	if opt == nil {
+		opt = options.OnConflict()
+	}
+	stmt.WriteString(" ON CONFLICT ")
+	if len(opt.Target) > 0 {
+		stmt.WriteByte('(')
+		for i, col := range opt.Target {
+			if i > 0 {
+				stmt.WriteByte(',')
+			}
+			stmt.WriteString(s.Quote(col))
+		}
+		stmt.WriteString(") ")
+	}
+
+	values := make([]primitive.KV, 0, len(columns))
+	for _, name := range onConflictColumns(columns, opt) {
+		values = append(values, primitive.KV{Field: name, Value: primitive.Inserted{Field: name}})
+	}
+	values = append(values, opt.Values...)
+	if len(values) < 1 {
+		// nothing to update without primary key, the conflict is ignored
+		if pk == "" {
+			stmt.WriteString("DO NOTHING")
+			return nil
+		}
+		// nothing to update, fallback to primary key so the statement stays valid
+		values = append(values, primitive.KV{Field: pk, Value: primitive.Inserted{Field: pk}})
+	}
+	stmt.WriteString("DO UPDATE SET ")
+
+	for i, kv := range values {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+		stmt.WriteString(s.Quote(kv.Field) + "=")
+		if err := s.buildValue(stmt, kv.Value); err != nil {
+			return err
+		}
+	}
+	if len(opt.Conditions.Values) > 0 {
+		stmt.WriteString(" WHERE ")
+		if err := s.parser.BuildStatement(stmt, opt.Conditions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updatableColumns : every column except primary key and omitted fields will be updated with the inserted value by default
+func updatableColumns(fields []reflext.StructFielder, pk string, omits map[string]bool) []string {
+	columns := make([]string, 0, len(fields))
+	for _, f := range fields {
+		name := f.Name()
+		if name == pk {
+			continue
+		}
+		if _, ok := f.Tag().LookUp("primary_key"); ok {
+			continue
+		}
+		if _, ok := f.Tag().LookUp("auto_increment"); ok {
+			continue
+		}
+		if _, ok := omits[name]; ok {
+			continue
+		}
+		columns = append(columns, name)
+	}
+	return columns
+}
+
+// onConflictColumns : the columns which will be updated with the inserted value
+func onConflictColumns(columns []string, opt *options.OnConflictOptions) []string {
+	if len(opt.Columns) > 0 {
+		return opt.Columns
+	}
+	if len(opt.Values) > 0 {
+		return nil
+	}
+	return columns
+}
+
+// buildValue : the value which doesn't have builder will be bound as argument
+func (s *SQLite) buildValue(stmt sqlstmt.Stmt, it interface{}) error {
+	if v := reflext.ValueOf(it); v.IsValid() {
+		if _, ok := s.parser.LookupBuilder(v.Type()); ok {
+			return s.parser.BuildStatement(stmt, it)
+		}
+	}
+	return s.parser.BuildStatement(stmt, primitive.Value{Raw: it})
+}
+
+// convertSpatial : sqlite doesn't have spatial data type, the geometry will be stored as well-known text
+func convertSpatial(stmt sqlstmt.Stmt, val interface{}) {
+	switch vi := val.(type) {
+	case spatial.Geometry:
+		stmt.AppendArgs(vi.WKT)
+	default:
+		stmt.AppendArgs(val)
+	}
+	stmt.WriteByte('?')
+}
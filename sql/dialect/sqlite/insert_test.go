@@ -0,0 +1,103 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/expr"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type insertStruct struct {
+	ID   int64 `sqlike:",auto_increment"`
+	Name string
+	Age  int
+}
+
+func TestInsertInto(t *testing.T) {
+	var (
+		s    = New()
+		data = []insertStruct{{Name: "john", Age: 18}, {ID: 10, Name: "doe", Age: 20}}
+		v    = reflect.ValueOf(data)
+		cdc  = reflext.DefaultMapper.CodecByType(v.Type().Elem())
+	)
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(stmt, "main", "users", "ID", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, options.Insert())
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "main"."users" ("ID","Name","Age") VALUES (NULL,?,?),(?,?,?);`, stmt.String())
+		require.ElementsMatch(t, []interface{}{"john", int64(18), int64(10), "doe", int64(20)}, stmt.Args())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(stmt, "main", "users", "ID", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, options.Insert().SetMode(options.InsertIgnore))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT OR IGNORE INTO "main"."users" ("ID","Name","Age") VALUES (NULL,?,?),(?,?,?);`, stmt.String())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(stmt, "main", "users", "ID", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, options.Insert().SetMode(options.InsertOnDuplicate))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "main"."users" ("ID","Name","Age") VALUES (NULL,?,?),(?,?,?) ON CONFLICT DO UPDATE SET "Name"=excluded."Name","Age"=excluded."Age";`, stmt.String())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertInto(stmt, "main", "users", "ID", reflext.DefaultMapper, codec.DefaultRegistry, cdc.Properties(), v, options.Insert().SetOnConflict(
+			options.OnConflict().
+				SetTarget("Name").
+				SetColumns("Age").
+				SetValues(expr.ColumnValue("Visits", expr.Increment("Visits", 1))).
+				SetWhere(expr.GreaterThan("Age", 10)),
+		))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "main"."users" ("ID","Name","Age") VALUES (NULL,?,?),(?,?,?) ON CONFLICT ("Name") DO UPDATE SET "Age"=excluded."Age","Visits"="Visits" + 1 WHERE "Age" > ?;`, stmt.String())
+		require.Equal(t, []interface{}{"john", int64(18), int64(10), "doe", int64(20), int64(10)}, stmt.Args())
+	}
+}
+
+func TestInsertFrom(t *testing.T) {
+	var (
+		s     = New()
+		query = sql.Select("Name", "Age").From("main", "archived_users")
+	)
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertFrom(stmt, "main", "users", "ID", []string{"Name", "Age"}, query, options.Insert().SetMode(options.InsertIgnore))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT OR IGNORE INTO "main"."users" ("Name","Age") SELECT "Name","Age" FROM "main"."archived_users";`, stmt.String())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertFrom(stmt, "main", "users", "ID", []string{"Name", "Age"}, query, options.Insert().SetOnConflict(
+			options.OnConflict().SetTarget("Name").SetColumns("Age"),
+		))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "main"."users" ("Name","Age") SELECT * FROM (SELECT "Name","Age" FROM "main"."archived_users") WHERE true ON CONFLICT ("Name") DO UPDATE SET "Age"=excluded."Age";`, stmt.String())
+	}
+
+	{
+		// nothing to update without primary key
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err := s.InsertFrom(stmt, "main", "users", "", nil, query, options.Insert().SetMode(options.InsertOnDuplicate))
+		require.NoError(t, err)
+		require.Equal(t, `INSERT INTO "main"."users" SELECT * FROM (SELECT "Name","Age" FROM "main"."archived_users") WHERE true ON CONFLICT DO NOTHING;`, stmt.String())
+	}
+}
@@ -0,0 +1,20 @@
+package sqlite
+
+import (
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+)
+
+// Savepoint :
+func (s *SQLite) Savepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("SAVEPOINT " + s.Quote(name) + ";")
+}
+
+// RollbackToSavepoint :
+func (s *SQLite) RollbackToSavepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("ROLLBACK TO SAVEPOINT " + s.Quote(name) + ";")
+}
+
+// ReleaseSavepoint :
+func (s *SQLite) ReleaseSavepoint(stmt sqlstmt.Stmt, name string) {
+	stmt.WriteString("RELEASE SAVEPOINT " + s.Quote(name) + ";")
+}
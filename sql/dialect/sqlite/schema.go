@@ -0,0 +1,220 @@
+package sqlite
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql/schema"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqltype "github.com/si3nloong/sqlike/sql/type"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/columns"
+	"github.com/si3nloong/sqlike/util"
+)
+
+// sqliteSchema : sqlite is using dynamic typing, so the data type is mapped to the type affinity
+// (`TEXT`, `INTEGER`, `REAL` and `BLOB`). Except `BOOLEAN`, `DATE` and `TIMESTAMP`, which
+// is recognised by the sql drivers to decode the value.
+type sqliteSchema struct {
+	sqlutil.SQLiteUtil
+}
+
+// SetBuilders :
+func (s sqliteSchema) SetBuilders(sb *schema.Builder) {
+	sb.SetTypeBuilder(sqltype.Byte, s.ByteDataType)
+	sb.SetTypeBuilder(sqltype.Date, s.DateDataType)
+	sb.SetTypeBuilder(sqltype.Time, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.DateTime, s.DateTimeDataType)
+	sb.SetTypeBuilder(sqltype.Timestamp, s.DateTimeDataType)
+	sb.SetTypeBuilder(sqltype.UUID, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.JSON, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.Point, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.LineString, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.Polygon, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.MultiPoint, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.MultiLineString, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.MultiPolygon, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.String, s.StringDataType)
+	sb.SetTypeBuilder(sqltype.Char, s.StringDataType)
+	sb.SetTypeBuilder(sqltype.Bool, s.BoolDataType)
+	sb.SetTypeBuilder(sqltype.Int, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int8, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int16, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int32, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Int64, s.IntDataType)
+	sb.SetTypeBuilder(sqltype.Uint, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint8, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint16, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint32, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Uint64, s.UintDataType)
+	sb.SetTypeBuilder(sqltype.Float32, s.FloatDataType)
+	sb.SetTypeBuilder(sqltype.Float64, s.FloatDataType)
+	sb.SetTypeBuilder(sqltype.Struct, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.Array, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.Slice, s.TextDataType)
+	sb.SetTypeBuilder(sqltype.Map, s.TextDataType)
+}
+
+func (s sqliteSchema) ByteDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "BLOB"
+	col.Type = "BLOB"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+func (s sqliteSchema) TextDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "TEXT"
+	col.Type = "TEXT"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+func (s sqliteSchema) DateDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "DATE"
+	col.Type = "DATE"
+	col.Nullable = sf.IsNullable()
+	return
+}
+
+// DateTimeDataType : sqlite doesn't support `ON UPDATE`, so the `on_update` tag is ignored
+func (s sqliteSchema) DateTimeDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "(CURRENT_TIMESTAMP)"
+	col.Name = sf.Name()
+	col.DataType = "TIMESTAMP"
+	col.Type = "TIMESTAMP"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s sqliteSchema) StringDataType(sf reflext.StructFielder) (col columns.Column) {
+	col.Name = sf.Name()
+	col.DataType = "TEXT"
+	col.Type = "TEXT"
+	col.Nullable = sf.IsNullable()
+
+	dflt := ""
+	tag := sf.Tag()
+	col.DefaultValue = &dflt
+	if v, ok := tag.LookUp("default"); ok {
+		col.DefaultValue = &v
+	}
+
+	if enum, ok := tag.LookUp("enum"); ok {
+		paths := strings.Split(enum, "|")
+		if len(paths) < 1 {
+			panic("invalid enum formats")
+		}
+
+		// sqlite doesn't have enum, we use check constraint instead
+		blr := util.AcquireString()
+		defer util.ReleaseString(blr)
+		blr.WriteString("CHECK (")
+		blr.WriteString(s.Quote(col.Name))
+		blr.WriteString(" IN (")
+		for i, p := range paths {
+			if i > 0 {
+				blr.WriteRune(',')
+			}
+			blr.WriteString(s.Wrap(p))
+		}
+		blr.WriteString("))")
+
+		dflt = paths[0]
+		col.Extra = blr.String()
+		col.DefaultValue = &dflt
+	} else if _, ok := tag.LookUp("longtext"); ok {
+		col.DefaultValue = nil
+	}
+	return
+}
+
+func (s sqliteSchema) BoolDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "0"
+	col.Name = sf.Name()
+	col.DataType = "BOOLEAN"
+	col.Type = "BOOLEAN"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	return
+}
+
+func (s sqliteSchema) IntDataType(sf reflext.StructFielder) (col columns.Column) {
+	tag := sf.Tag()
+	dflt := "0"
+	col.Name = sf.Name()
+	col.DataType = "INTEGER"
+	col.Type = "INTEGER"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if _, ok := tag.LookUp("auto_increment"); ok {
+		col.Extra = "AUTOINCREMENT"
+		col.DefaultValue = nil
+	} else if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseInt(v, 10, 64); err != nil {
+			panic("int default value should be integer")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s sqliteSchema) UintDataType(sf reflext.StructFielder) (col columns.Column) {
+	tag := sf.Tag()
+	dflt := "0"
+	col.Name = sf.Name()
+	col.DataType = "INTEGER"
+	col.Type = "INTEGER"
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	col.Extra = "CHECK (" + s.Quote(col.Name) + " >= 0)"
+	if _, ok := tag.LookUp("auto_increment"); ok {
+		col.Extra = "AUTOINCREMENT"
+		col.DefaultValue = nil
+	} else if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseUint(v, 10, 64); err != nil {
+			panic("uint default value should be unsigned integer")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s sqliteSchema) FloatDataType(sf reflext.StructFielder) (col columns.Column) {
+	dflt := "0"
+	tag := sf.Tag()
+	col.Name = sf.Name()
+	col.DataType = "REAL"
+	col.Type = "REAL"
+	if _, ok := tag.LookUp("unsigned"); ok {
+		col.Extra = "CHECK (" + s.Quote(col.Name) + " >= 0)"
+	}
+	col.Nullable = sf.IsNullable()
+	col.DefaultValue = &dflt
+	if v, ok := tag.LookUp("default"); ok {
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			panic("float default value should be decimal number")
+		}
+		col.DefaultValue = &v
+	}
+	return
+}
+
+func (s *SQLite) buildSchemaByColumn(stmt sqlstmt.Stmt, col columns.Column) {
+	stmt.WriteString(s.Quote(col.Name))
+	stmt.WriteString(" " + col.Type)
+	// `AUTOINCREMENT` is only allowed on primary key, which is handled by `CreateTable`
+	if col.Extra != "" && col.Extra != "AUTOINCREMENT" {
+		stmt.WriteString(" " + col.Extra)
+	}
+	if !col.Nullable {
+		stmt.WriteString(" NOT NULL")
+		if col.DefaultValue != nil {
+			stmt.WriteString(" DEFAULT " + s.WrapOnlyValue(*col.DefaultValue))
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package sqlite
+
+import (
+	"fmt"
+
+	semver "github.com/Masterminds/semver/v3"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+// Select : sqlite doesn't have row level lock, the whole database is locked when writing, so the lock mode is ignored.
+func (s *SQLite) Select(stmt sqlstmt.Stmt, f *actions.FindActions, lck options.LockMode, of ...string) (err error) {
+	err = s.parser.BuildStatement(stmt, f)
+	if err != nil {
+		return
+	}
+	stmt.WriteByte(';')
+	return
+}
+
+// ValidateLock : `FOR UPDATE` and `FOR SHARE` are ignored since the whole database is locked when writing,
+// but `NOWAIT`, `SKIP LOCKED` and `OF` are not supported because they can't be fulfilled.
+func (s *SQLite) ValidateLock(version *semver.Version, lck options.LockMode, of []string) error {
+	if lck == options.NoLock {
+		return nil
+	}
+	switch lck {
+	case options.LockForUpdate, options.LockForRead:
+		if len(of) < 1 {
+			return nil
+		}
+	}
+	mode := lck.String()
+	if len(of) > 0 {
+		mode += " OF"
+	}
+	return fmt.Errorf("sqlite: lock %q is not supported", mode)
+}
+
+// SelectStmt :
+func (s *SQLite) SelectStmt(stmt sqlstmt.Stmt, query interface{}) (err error) {
+	err = s.parser.BuildStatement(stmt, query)
+	stmt.WriteByte(';')
+	return
+}
+
+func buildStatement(stmt sqlstmt.Stmt, parser *sqlstmt.StatementBuilder, f interface{}) error {
+	if err := parser.BuildStatement(stmt, f); err != nil {
+		return err
+	}
+	stmt.WriteByte(';')
+	return nil
+}
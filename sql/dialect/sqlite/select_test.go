@@ -0,0 +1,158 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/si3nloong/sqlike/sql/expr"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelect(t *testing.T) {
+	var (
+		now = time.Date(2020, 1, 3, 12, 0, 40, 0, time.UTC)
+		err error
+	)
+
+	{
+		s := New()
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = s.Select(
+			stmt,
+			actions.Find().From("main", "Test").
+				Where(
+					expr.And(
+						expr.Equal("A", 1),
+						expr.Like("B", "abc%"),
+						expr.Between("DateTime", now, now.Add(5*time.Minute)),
+					),
+					expr.Equal("E", uint(888)),
+				).
+				OrderBy(expr.Desc("A")).
+				Limit(10).(*actions.FindActions), options.LockForUpdate,
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT * FROM "main"."Test" WHERE (("A" = ? AND "B" LIKE ? ESCAPE '\' AND "DateTime" BETWEEN ? AND ?) AND "E" = ?) ORDER BY "A" DESC LIMIT 10;`, stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(1), "abc%", now, now.Add(5 * time.Minute), uint64(888)}, stmt.Args())
+	}
+
+	{
+		s := New()
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = s.Select(
+			stmt,
+			actions.Find().From("main", "Test").
+				Where(
+					expr.MemberOf("x", expr.Column("Tags")),
+					expr.Equal(expr.JSONColumn("Address", "State"), "Selangor"),
+				).(*actions.FindActions), options.NoLock,
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT * FROM "main"."Test" WHERE (EXISTS(SELECT 1 FROM JSON_EACH("Tags") WHERE value = ?) AND "Address"->'$.State' = ?);`, stmt.String())
+		require.ElementsMatch(t, []interface{}{"x", "Selangor"}, stmt.Args())
+	}
+
+	{
+		dl := New()
+		stmt := sqlstmt.AcquireStmt(dl)
+		defer sqlstmt.ReleaseStmt(stmt)
+		err = dl.Select(
+			stmt,
+			actions.Find().
+				Select(expr.Qualified("User", "ID"), expr.Qualified("o", "Amount")).
+				From("db", "User").
+				LeftJoin(expr.As("Order", "o")).
+				On(expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID"))).
+				Where(expr.GreaterThan(expr.Column("o", "Amount"), 10)).(*actions.FindActions), 0,
+		)
+		require.NoError(t, err)
+		require.Equal(t, `SELECT ("User"."ID") AS "User.ID",("o"."Amount") AS "o.Amount" FROM "db"."User" LEFT JOIN "db"."Order" AS "o" ON "o"."UserID" = "User"."ID" WHERE "o"."Amount" > ?;`, stmt.String())
+		require.ElementsMatch(t, []interface{}{int64(10)}, stmt.Args())
+	}
+}
+
+func TestValidateLock(t *testing.T) {
+	s := New()
+	require.NoError(t, s.ValidateLock(nil, options.NoLock, nil))
+	require.NoError(t, s.ValidateLock(nil, options.LockForUpdate, nil))
+	require.NoError(t, s.ValidateLock(nil, options.LockForRead, nil))
+	require.EqualError(t, s.ValidateLock(nil, options.LockForUpdateNoWait, nil), `sqlite: lock "FOR UPDATE NOWAIT" is not supported`)
+	require.EqualError(t, s.ValidateLock(nil, options.LockForUpdateSkipLocked, nil), `sqlite: lock "FOR UPDATE SKIP LOCKED" is not supported`)
+	require.EqualError(t, s.ValidateLock(nil, options.LockForShareSkipLocked, nil), `sqlite: lock "FOR SHARE SKIP LOCKED" is not supported`)
+	require.EqualError(t, s.ValidateLock(nil, options.LockForUpdate, []string{"Job"}), `sqlite: lock "FOR UPDATE OF" is not supported`)
+}
+
+func TestUpdate(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	act := actions.Update().
+		Where(expr.Equal("A", "x")).
+		Set(expr.ColumnValue("B", 10)).
+		OrderBy(expr.Desc("C")).
+		Limit(1).(*actions.UpdateActions)
+	act.Database = "main"
+	act.Table = "table"
+	err := s.Update(stmt, act)
+	require.NoError(t, err)
+	require.Equal(t, `UPDATE "main"."table" SET "B" = ? WHERE rowid IN (SELECT rowid FROM "main"."table" WHERE "A" = ? ORDER BY "C" DESC LIMIT 1);`, stmt.String())
+	require.ElementsMatch(t, []interface{}{int64(10), "x"}, stmt.Args())
+}
+
+func TestDelete(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	act := actions.Delete().
+		Where(expr.Equal("A", "x")).(*actions.DeleteActions)
+	act.Database = "main"
+	act.Table = "table"
+	err := s.Delete(stmt, act)
+	require.NoError(t, err)
+	require.Equal(t, `DELETE FROM "main"."table" WHERE "A" = ?;`, stmt.String())
+	require.ElementsMatch(t, []interface{}{"x"}, stmt.Args())
+}
+
+func TestUpdateAndDeleteWithJoin(t *testing.T) {
+	s := New()
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		act := actions.Update().
+			InnerJoin(expr.As("Order", "o")).
+			On(expr.Equal(expr.Column("o", "UserID"), expr.Column("User", "ID"))).
+			Set(expr.ColumnValue("User.Status", "VIP")).(*actions.UpdateActions)
+		act.Database = "main"
+		act.Table = "User"
+		err := s.Update(stmt, act)
+		require.NoError(t, err)
+		require.Equal(t, `UPDATE "main"."User" SET "Status" = ? FROM "main"."Order" AS "o" WHERE "o"."UserID" = "User"."ID";`, stmt.String())
+
+		// the aliased table name is qualified the same way as expr.As
+		stmt.Reset()
+		act.Joins[0].Table = primitive.As{Field: "Order", Name: "o"}
+		require.NoError(t, s.Update(stmt, act))
+		require.Equal(t, `UPDATE "main"."User" SET "Status" = ? FROM "main"."Order" AS "o" WHERE "o"."UserID" = "User"."ID";`, stmt.String())
+	}
+
+	{
+		stmt := sqlstmt.AcquireStmt(s)
+		defer sqlstmt.ReleaseStmt(stmt)
+		act := actions.Delete().
+			InnerJoin("Order").
+			On(expr.Equal(expr.Column("Order", "UserID"), expr.Column("User", "ID"))).(*actions.DeleteActions)
+		act.Database = "main"
+		act.Table = "User"
+		err := s.Delete(stmt, act)
+		require.Error(t, err)
+	}
+}
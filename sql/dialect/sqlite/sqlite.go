@@ -0,0 +1,38 @@
+package sqlite
+
+import (
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/dialect"
+	"github.com/si3nloong/sqlike/sql/schema"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+)
+
+// SQLite : the sqlite dialect. The sqlike `Database` is mapped to a sqlite schema,
+// where `main` is the database file you connected to and others are attached databases.
+type SQLite struct {
+	schema *schema.Builder
+	parser *sqlstmt.StatementBuilder
+	sqlutil.SQLiteUtil
+}
+
+var _ dialect.Dialect = (*(SQLite))(nil)
+
+// New :
+func New() *SQLite {
+	sb := schema.NewBuilder()
+	pr := sqlstmt.NewStatementBuilder()
+
+	sqliteSchema{}.SetBuilders(sb)
+	sqliteBuilder{}.SetRegistryAndBuilders(codec.DefaultRegistry, pr)
+
+	return &SQLite{
+		schema: sb,
+		parser: pr,
+	}
+}
+
+// GetVersion :
+func (s *SQLite) GetVersion(stmt sqlstmt.Stmt) {
+	stmt.WriteString("SELECT SQLITE_VERSION();")
+}
@@ -0,0 +1,17 @@
+package sqlite
+
+import (
+	"testing"
+
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetVersion(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.GetVersion(stmt)
+	require.Equal(t, "SELECT SQLITE_VERSION();", stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
@@ -0,0 +1,285 @@
+package sqlite
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql/driver"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/columns"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+	"github.com/si3nloong/sqlike/sqlike/indexes"
+)
+
+// HasPrimaryKey :
+func (s *SQLite) HasPrimaryKey(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("SELECT COUNT(*) FROM pragma_table_info(?, ?) WHERE pk > 0;")
+	stmt.AppendArgs(table, db)
+}
+
+// RenameTable :
+func (s *SQLite) RenameTable(stmt sqlstmt.Stmt, db, oldName, newName string) {
+	stmt.WriteString("ALTER TABLE ")
+	stmt.WriteString(s.TableName(db, oldName))
+	stmt.WriteString(" RENAME TO ")
+	stmt.WriteString(s.Quote(newName))
+	stmt.WriteByte(';')
+}
+
+// DropTable :
+func (s *SQLite) DropTable(stmt sqlstmt.Stmt, db, table string, exists bool) {
+	stmt.WriteString("DROP TABLE")
+	if exists {
+		stmt.WriteString(" IF EXISTS")
+	}
+	stmt.WriteByte(' ')
+	stmt.WriteString(s.TableName(db, table) + ";")
+}
+
+// TruncateTable : sqlite doesn't have `TRUNCATE`, an unqualified `DELETE` will be optimised to truncate the table.
+func (s *SQLite) TruncateTable(stmt sqlstmt.Stmt, db, table string) {
+	stmt.WriteString("DELETE FROM " + s.TableName(db, table) + ";")
+}
+
+// HasTable :
+func (s *SQLite) HasTable(stmt sqlstmt.Stmt, dbName, table string) {
+	stmt.WriteString(`SELECT COUNT(*) FROM ` + s.Quote(dbName) + `.sqlite_master WHERE type = 'table' AND name = ?;`)
+	stmt.AppendArgs(table)
+}
+
+// CreateTable :
+func (s *SQLite) CreateTable(stmt sqlstmt.Stmt, db, table, pk string, info driver.Info, fields []reflext.StructFielder) (err error) {
+	var (
+		col     columns.Column
+		pkk     reflext.StructFielder
+		k1, k2  string
+		virtual bool
+		stored  bool
+		uniques []string
+	)
+
+	fks, err := foreignkeys.FromFields(fields)
+	if err != nil {
+		return err
+	}
+
+	// primary key must be resolved first, because `AUTOINCREMENT` has to be declared on the column itself
+	for _, sf := range fields {
+		tag := sf.Tag()
+		// allow primary_key tag to override
+		if _, ok := tag.LookUp("primary_key"); ok {
+			pkk = sf
+			break
+		} else if _, ok := tag.LookUp("auto_increment"); ok {
+			pkk = sf
+		} else if sf.Name() == pk && pkk == nil {
+			pkk = sf
+		}
+	}
+
+	stmt.WriteString("CREATE TABLE " + s.TableName(db, table) + " ")
+	stmt.WriteByte('(')
+
+	// Main columns :
+	for i, sf := range fields {
+		if i > 0 {
+			stmt.WriteByte(',')
+		}
+
+		col, err = s.schema.GetColumn(info, sf)
+		if err != nil {
+			return
+		}
+
+		tag := sf.Tag()
+		if _, ok := tag.LookUp("unique_index"); ok {
+			uniques = append(uniques, s.uniqueIndex(db, table, sf.Name()))
+		}
+
+		if pkk == sf && col.Extra == "AUTOINCREMENT" {
+			// sqlite only allow `AUTOINCREMENT` on `INTEGER PRIMARY KEY`
+			stmt.WriteString(s.Quote(col.Name) + " INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL")
+			pkk = nil
+		} else {
+			s.buildSchemaByColumn(stmt, col)
+		}
+
+		// check generated columns
+		t := reflext.Deref(sf.Type())
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			tg := child.Tag()
+			k1, virtual = tg.LookUp("virtual_column")
+			k2, stored = tg.LookUp("stored_column")
+			if virtual || stored {
+				stmt.WriteByte(',')
+				col, err = s.schema.GetColumn(info, child)
+				if err != nil {
+					return
+				}
+
+				name := col.Name
+				if virtual && k1 != "" {
+					name = k1
+				}
+				if stored && k2 != "" {
+					name = k2
+				}
+
+				s.buildGeneratedColumn(stmt, sf, child, name, col, stored)
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+
+	}
+	if pkk != nil {
+		stmt.WriteByte(',')
+		stmt.WriteString("PRIMARY KEY (" + s.Quote(pkk.Name()) + ")")
+	}
+	for _, fk := range fks {
+		stmt.WriteByte(',')
+		s.buildForeignKey(stmt, table, fk)
+	}
+	stmt.WriteByte(')')
+	stmt.WriteByte(';')
+	// unique constraint will be named as `sqlite_autoindex_*`, so we create the unique index separately
+	for _, u := range uniques {
+		stmt.WriteString(u)
+	}
+	return
+}
+
+// AlterTable : sqlite only able to add or drop column using `ALTER TABLE`, the existing columns will remain untouched.
+// Every alteration is a separate statement, the statement will be empty if there is nothing to alter.
+// Foreign key only can be declared on `CREATE TABLE`, so it returns `ErrAlterForeignKey` if there is foreign key to add or drop.
+// The `DROP COLUMN` clauses which drop data are returned.
+func (s *SQLite) AlterTable(stmt sqlstmt.Stmt, db, table, pk string, hasPk bool, info driver.Info, fields []reflext.StructFielder, cols util.StringSlice, idxs util.StringSlice, fkNames util.StringSlice, unsafe bool) (drops []string, err error) {
+	var (
+		col     columns.Column
+		idx     int
+		k1, k2  string
+		virtual bool
+		stored  bool
+		uniques []string
+	)
+
+	fks, err := foreignkeys.FromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	if adds, drops := foreignkeys.Diff(table, fks, fkNames, unsafe); len(adds) > 0 || len(drops) > 0 {
+		return nil, ErrAlterForeignKey
+	}
+
+	for _, sf := range fields {
+		idx = cols.IndexOf(sf.Name())
+		exists := idx > -1
+		if exists {
+			cols.Splice(idx)
+		}
+
+		if _, ok := sf.Tag().LookUp("unique_index"); ok {
+			idx := indexes.Index{Type: indexes.Unique, Columns: indexes.Columns(sf.Name())}
+			if idxs.IndexOf(s.indexName(table, idx)) < 0 {
+				uniques = append(uniques, s.uniqueIndex(db, table, sf.Name()))
+			}
+		}
+
+		if !exists {
+			col, err = s.schema.GetColumn(info, sf)
+			if err != nil {
+				return
+			}
+			// sqlite requires a default value when adding a not null column
+			if !col.Nullable && col.DefaultValue == nil {
+				dflt := ""
+				col.DefaultValue = &dflt
+			}
+			stmt.WriteString("ALTER TABLE " + s.TableName(db, table) + " ADD COLUMN ")
+			s.buildSchemaByColumn(stmt, col)
+			stmt.WriteByte(';')
+		}
+
+		// check generated columns
+		t := reflext.Deref(sf.Type())
+		if t.Kind() != reflect.Struct {
+			continue
+		}
+
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			tg := child.Tag()
+			k1, virtual = tg.LookUp("virtual_column")
+			k2, stored = tg.LookUp("stored_column")
+			if virtual || stored {
+				col, err = s.schema.GetColumn(info, child)
+				if err != nil {
+					return
+				}
+
+				name := col.Name
+				if virtual && k1 != "" {
+					name = k1
+				}
+				if stored && k2 != "" {
+					name = k2
+				}
+
+				idx = cols.IndexOf(name)
+				if idx > -1 {
+					cols.Splice(idx)
+				} else if virtual {
+					// sqlite doesn't allow to add stored generated column on existing table
+					stmt.WriteString("ALTER TABLE " + s.TableName(db, table) + " ADD COLUMN ")
+					s.buildGeneratedColumn(stmt, sf, child, name, col, false)
+					stmt.WriteByte(';')
+				}
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+	}
+
+	for _, u := range uniques {
+		stmt.WriteString(u)
+	}
+
+	if unsafe {
+		for _, col := range cols {
+			clause := "DROP COLUMN " + s.Quote(col)
+			stmt.WriteString("ALTER TABLE " + s.TableName(db, table) + " " + clause + ";")
+			drops = append(drops, clause)
+		}
+	}
+	return
+}
+
+func (s *SQLite) uniqueIndex(db, table, column string) string {
+	idx := indexes.Index{Type: indexes.Unique, Columns: indexes.Columns(column)}
+	return "CREATE UNIQUE INDEX " + s.TableName(db, s.indexName(table, idx)) + " ON " + s.Quote(table) + " (" + s.Quote(column) + ");"
+}
+
+func (s *SQLite) buildGeneratedColumn(stmt sqlstmt.Stmt, parent, child reflext.StructFielder, name string, col columns.Column, stored bool) {
+	path := strings.TrimLeft(strings.TrimPrefix(child.Name(), parent.Name()), ".")
+	stmt.WriteString(s.Quote(name))
+	stmt.WriteString(" " + col.Type)
+	stmt.WriteString(" GENERATED ALWAYS AS ")
+	stmt.WriteString("(" + s.Quote(parent.Name()) + "->>" + s.Wrap("$."+path) + ")")
+	if stored {
+		stmt.WriteString(" STORED")
+	} else {
+		stmt.WriteString(" VIRTUAL")
+	}
+	if !col.Nullable {
+		stmt.WriteString(" NOT NULL")
+	}
+}
@@ -0,0 +1,138 @@
+package sqlite
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql/charset"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sql/util"
+	"github.com/stretchr/testify/require"
+)
+
+type driverInfo struct{}
+
+func (driverInfo) DriverName() string       { return "sqlite" }
+func (driverInfo) Version() *semver.Version { return nil }
+func (driverInfo) Charset() charset.Code    { return charset.UTF8MB4 }
+func (driverInfo) Collate() string          { return "" }
+
+type normalStruct struct {
+	ID        int64  `sqlike:",auto_increment"`
+	Email     string `sqlike:",unique_index"`
+	Status    string `sqlike:",enum=active|inactive"`
+	Age       uint8
+	Flag      bool
+	Meta      map[string]string
+	CreatedAt time.Time
+	Remark    *string
+}
+
+func TestHasPrimaryKey(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	s.HasPrimaryKey(stmt, "main", "table")
+	require.Equal(t, "SELECT COUNT(*) FROM pragma_table_info(?, ?) WHERE pk > 0;", stmt.String())
+	require.ElementsMatch(t, []interface{}{"table", "main"}, stmt.Args())
+}
+
+func TestDropTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	{
+		s.DropTable(stmt, "main", "table", true)
+		require.Equal(t, `DROP TABLE IF EXISTS "main"."table";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+
+	stmt.Reset()
+
+	{
+		s.DropTable(stmt, "main", "table", false)
+		require.Equal(t, `DROP TABLE "main"."table";`, stmt.String())
+		require.ElementsMatch(t, []interface{}{}, stmt.Args())
+	}
+}
+
+func TestRenameTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.RenameTable(stmt, "main", "oldName", "newName")
+	require.Equal(t, `ALTER TABLE "main"."oldName" RENAME TO "newName";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestTruncateTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.TruncateTable(stmt, "main", "table")
+	require.Equal(t, `DELETE FROM "main"."table";`, stmt.String())
+	require.ElementsMatch(t, []interface{}{}, stmt.Args())
+}
+
+func TestHasTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+	s.HasTable(stmt, "main", "table")
+	require.Equal(t, `SELECT COUNT(*) FROM "main".sqlite_master WHERE type = 'table' AND name = ?;`, stmt.String())
+	require.ElementsMatch(t, []interface{}{"table"}, stmt.Args())
+}
+
+func TestCreateTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	cdc := reflext.DefaultMapper.CodecByType(reflect.TypeOf(normalStruct{}))
+	err := s.CreateTable(stmt, "main", "users", "$Key", driverInfo{}, cdc.Properties())
+	require.NoError(t, err)
+	require.Equal(t, `CREATE TABLE "main"."users" (`+
+		`"ID" INTEGER PRIMARY KEY AUTOINCREMENT NOT NULL,`+
+		`"Email" TEXT NOT NULL DEFAULT '',`+
+		`"Status" TEXT CHECK ("Status" IN ('active','inactive')) NOT NULL DEFAULT 'active',`+
+		`"Age" INTEGER CHECK ("Age" >= 0) NOT NULL DEFAULT '0',`+
+		`"Flag" BOOLEAN NOT NULL DEFAULT '0',`+
+		`"Meta" TEXT,`+
+		`"CreatedAt" TIMESTAMP NOT NULL DEFAULT (CURRENT_TIMESTAMP),`+
+		`"Remark" TEXT);`+
+		`CREATE UNIQUE INDEX "main"."users_`+"b0efec6c99db359eb469de79dca87cf6"+`" ON "users" ("Email");`, stmt.String())
+}
+
+func TestAlterTable(t *testing.T) {
+	s := New()
+	stmt := sqlstmt.AcquireStmt(s)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	cdc := reflext.DefaultMapper.CodecByType(reflect.TypeOf(normalStruct{}))
+
+	{
+		drops, err := s.AlterTable(stmt, "main", "users", "$Key", true, driverInfo{}, cdc.Properties(),
+			util.StringSlice{"ID", "Email", "Status", "Age", "Flag", "Meta", "CreatedAt", "Remark"},
+			util.StringSlice{}, util.StringSlice{}, false)
+		require.NoError(t, err)
+		require.Empty(t, drops)
+		require.Contains(t, stmt.String(), `CREATE UNIQUE INDEX "main"."users_`)
+	}
+
+	stmt.Reset()
+
+	{
+		drops, err := s.AlterTable(stmt, "main", "users", "$Key", true, driverInfo{}, cdc.Properties(),
+			util.StringSlice{"ID", "Email", "Status", "Age", "Flag", "CreatedAt", "Remark", "Deprecated"},
+			util.StringSlice{}, util.StringSlice{}, true)
+		require.NoError(t, err)
+		require.Equal(t, []string{`DROP COLUMN "Deprecated"`}, drops)
+		require.Contains(t, stmt.String(), `ALTER TABLE "main"."users" ADD COLUMN "Meta" TEXT;`)
+		require.Contains(t, stmt.String(), `ALTER TABLE "main"."users" DROP COLUMN "Deprecated";`)
+	}
+}
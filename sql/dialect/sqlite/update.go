@@ -0,0 +1,15 @@
+package sqlite
+
+import (
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+)
+
+// Update :
+func (s *SQLite) Update(stmt sqlstmt.Stmt, f *actions.UpdateActions) (err error) {
+	err = buildStatement(stmt, s.parser, f)
+	if err != nil {
+		return
+	}
+	return
+}
@@ -0,0 +1,40 @@
+package sqlite
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	var (
+		s   = New()
+		str string
+	)
+
+	str = s.Format(int64(-638731231286))
+	require.Equal(t, "-638731231286", str)
+
+	str = s.Format(uint64(638731231286))
+	require.Equal(t, "638731231286", str)
+
+	str = s.Format("it's a string")
+	require.Equal(t, `'it''s a string'`, str)
+
+	str = s.Format(true)
+	require.Equal(t, "1", str)
+
+	str = s.Format(false)
+	require.Equal(t, "0", str)
+
+	str = s.Format(nil)
+	require.Equal(t, "NULL", str)
+
+	ts, _ := time.Parse("2006-01-02 15:04:05", "2020-01-03 12:00:40")
+	str = s.Format(ts)
+	require.Equal(t, `'2020-01-03 12:00:40'`, str)
+
+	str = s.Format([]byte("hello world"))
+	require.Equal(t, `'hello world'`, str)
+}
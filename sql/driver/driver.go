@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	semver "github.com/Masterminds/semver/v3"
 	"github.com/si3nloong/sqlike/sql/charset"
 	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
 	"github.com/si3nloong/sqlike/sqlike/logs"
@@ -12,6 +13,7 @@ import (
 // Info :
 type Info interface {
 	DriverName() string
+	Version() *semver.Version
 	Charset() charset.Code
 	Collate() string
 }
@@ -1,6 +1,8 @@
 package expr
 
 import (
+	"strconv"
+
 	"github.com/si3nloong/sqlike/sqlike/primitive"
 )
 
@@ -38,3 +40,86 @@ func Min(field interface{}) (a primitive.Aggregate) {
 	a.By = primitive.Min
 	return
 }
+
+// RowNumber : window function, must be used with `Over`
+func RowNumber() (a primitive.Aggregate) {
+	a.By = primitive.RowNumber
+	return
+}
+
+// Rank : window function, must be used with `Over`
+func Rank() (a primitive.Aggregate) {
+	a.By = primitive.Rank
+	return
+}
+
+// DenseRank : window function, must be used with `Over`
+func DenseRank() (a primitive.Aggregate) {
+	a.By = primitive.DenseRank
+	return
+}
+
+// Lag : window function, value of the field from the row `offset` rows before the current row
+func Lag(field interface{}, offset uint, defaultValue ...interface{}) (a primitive.Aggregate) {
+	a = offsetFunc(field, offset, defaultValue)
+	a.By = primitive.Lag
+	return
+}
+
+// Lead : window function, value of the field from the row `offset` rows after the current row
+func Lead(field interface{}, offset uint, defaultValue ...interface{}) (a primitive.Aggregate) {
+	a = offsetFunc(field, offset, defaultValue)
+	a.By = primitive.Lead
+	return
+}
+
+// FirstValue : window function, must be used with `Over`
+func FirstValue(field interface{}) (a primitive.Aggregate) {
+	a.Field = wrapColumn(field)
+	a.By = primitive.FirstValue
+	return
+}
+
+// Rows : window frame in rows, eg. `expr.Rows(expr.UnboundedPreceding(), expr.CurrentRow())`
+func Rows(start, end interface{}) *primitive.Frame {
+	return &primitive.Frame{Unit: primitive.Rows, Start: start, End: end}
+}
+
+// Range : window frame in range
+func Range(start, end interface{}) *primitive.Frame {
+	return &primitive.Frame{Unit: primitive.Range, Start: start, End: end}
+}
+
+// UnboundedPreceding :
+func UnboundedPreceding() primitive.Raw {
+	return Raw("UNBOUNDED PRECEDING")
+}
+
+// Preceding :
+func Preceding(n uint) primitive.Raw {
+	return Raw(strconv.FormatUint(uint64(n), 10) + " PRECEDING")
+}
+
+// CurrentRow :
+func CurrentRow() primitive.Raw {
+	return Raw("CURRENT ROW")
+}
+
+// Following :
+func Following(n uint) primitive.Raw {
+	return Raw(strconv.FormatUint(uint64(n), 10) + " FOLLOWING")
+}
+
+// UnboundedFollowing :
+func UnboundedFollowing() primitive.Raw {
+	return Raw("UNBOUNDED FOLLOWING")
+}
+
+func offsetFunc(field interface{}, offset uint, defaultValue []interface{}) (a primitive.Aggregate) {
+	a.Field = wrapColumn(field)
+	a.Args = append(a.Args, Raw(strconv.FormatUint(uint64(offset), 10)))
+	if len(defaultValue) > 0 {
+		a.Args = append(a.Args, wrapRaw(defaultValue[0]))
+	}
+	return
+}
@@ -14,3 +14,21 @@ func TestSum(t *testing.T) {
 	}, Sum("a"))
 	return
 }
+
+func TestWindow(t *testing.T) {
+	require.Equal(t, primitive.Aggregate{
+		Field: wrapColumn("a"),
+		By:    primitive.Lag,
+		Args:  []interface{}{Raw("2"), primitive.Value{Raw: 0}},
+	}, Lag("a", 2, 0))
+
+	w := RowNumber().Over([]interface{}{"a"}, []interface{}{Desc("b")}, Rows(UnboundedPreceding(), CurrentRow()))
+	require.Equal(t, primitive.RowNumber, w.Func.By)
+	require.Equal(t, []interface{}{"a"}, w.PartitionBy)
+	require.Equal(t, []interface{}{Desc("b")}, w.OrderBy)
+	require.Equal(t, &primitive.Frame{
+		Unit:  primitive.Rows,
+		Start: Raw("UNBOUNDED PRECEDING"),
+		End:   Raw("CURRENT ROW"),
+	}, w.Frame)
+}
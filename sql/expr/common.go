@@ -31,6 +31,19 @@ func Column(name string, alias ...string) (c primitive.Column) {
 	return
 }
 
+// Qualified : table-qualified column aliased as `table.column`, so the joined row can be decoded into nested struct
+func Qualified(table, column string) (as primitive.As) {
+	as.Field = primitive.Column{Table: table, Name: column}
+	as.Name = table + "." + column
+	return
+}
+
+// Inserted : the value of column proposed for insertion on upsert, `VALUES(column)` in mysql and `EXCLUDED.column` in postgres and sqlite
+func Inserted(field string) (i primitive.Inserted) {
+	i.Field = field
+	return
+}
+
 // Func :
 func Func(name string, value interface{}, others ...interface{}) (f primitive.Func) {
 	f.Name = strings.ToUpper(strings.TrimSpace(name))
@@ -12,11 +12,11 @@ func Union(stmt1 selectStmt, stmt2 selectStmt, others ...selectStmt) (grp primit
 	return
 }
 
-// // UnionAll :
-// func UnionAll(stmt1 *sql.SelectStmt, stmt2 *sql.SelectStmt, others ...*sql.SelectStmt) (grp primitive.Group) {
-// 	grp = union(Raw("UNION ALL"), append([]*sql.SelectStmt{stmt1, stmt2}, others...))
-// 	return
-// }
+// UnionAll :
+func UnionAll(stmt1 selectStmt, stmt2 selectStmt, others ...selectStmt) (grp primitive.Group) {
+	grp = union(Raw(" UNION ALL "), append([]selectStmt{stmt1, stmt2}, others...))
+	return
+}
 
 // Exists :
 func Exists(subquery interface{}) (grp primitive.Group) {
@@ -39,6 +39,22 @@ func Case() *primitive.Case {
 	return new(primitive.Case)
 }
 
+// full-text search modifiers of `Match`
+const (
+	NaturalLanguageMode = primitive.NaturalLanguageMode
+	BooleanMode         = primitive.BooleanMode
+	QueryExpansionMode  = primitive.QueryExpansionMode
+)
+
+// Match : full-text search, eg. `expr.Match("Title", "Body").Against("database", expr.BooleanMode)`
+func Match(columns ...interface{}) *primitive.Match {
+	m := new(primitive.Match)
+	for _, col := range columns {
+		m.Columns = append(m.Columns, wrapColumn(col))
+	}
+	return m
+}
+
 func union(link primitive.Raw, stmts []selectStmt) (grp primitive.Group) {
 	for i, stmt := range stmts {
 		if i > 0 {
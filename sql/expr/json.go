@@ -2,6 +2,7 @@ package expr
 
 import (
 	"encoding/json"
+	"errors"
 
 	"github.com/si3nloong/sqlike/sqlike/primitive"
 )
@@ -105,7 +106,10 @@ func JSON_VALID(val interface{}) (f primitive.JSONFunc) {
 	return
 }
 
-// JSON_CONTAINS :
+// JSON_CONTAINS : unlike `JSONContains`, the string target is a value instead of column,
+// eg. `expr.JSON_CONTAINS(expr.Column("Tags"), `"a"`)`.
+//
+// Deprecated: use `JSONContains` instead, which encodes the candidate as json document.
 func JSON_CONTAINS(target, candidate interface{}, paths ...string) (f primitive.JSONFunc) {
 	f.Type = primitive.JSON_CONTAINS
 	for _, arg := range []interface{}{target, candidate} {
@@ -143,6 +147,62 @@ func MemberOf(val interface{}, arr interface{}) (f primitive.JSONFunc) {
 	return
 }
 
+// JSONContains : the string target is a column name, and the candidate is encoded as json document unless it's a column or expression,
+// eg. `expr.JSONContains(expr.JSONColumn("Info", "tags"), []string{"a", "b"})`
+func JSONContains(target, candidate interface{}, paths ...string) (f primitive.JSONFunc) {
+	f.Type = primitive.JSON_CONTAINS
+	f.Args = append(f.Args, wrapColumn(target), wrapJSONValue(candidate))
+	for _, p := range paths {
+		f.Args = append(f.Args, wrapRaw(p))
+	}
+	return
+}
+
+// JSONOverlaps : mysql 8.0.17
+func JSONOverlaps(doc, candidate interface{}) (f primitive.JSONFunc) {
+	f.Type = primitive.JSON_OVERLAPS
+	f.Args = append(f.Args, wrapColumn(doc), wrapJSONValue(candidate))
+	return
+}
+
+// JSONSearch : the `oneOrAll` can be either "one" or "all", the search string can have `%` and `_` wildcard
+func JSONSearch(doc interface{}, oneOrAll string, search string, paths ...string) (f primitive.JSONFunc) {
+	f.Type = primitive.JSON_SEARCH
+	f.Args = append(f.Args, wrapColumn(doc), wrapRaw(oneOrAll), wrapRaw(search))
+	if len(paths) > 0 {
+		// the escape character must be specified before paths, NULL is the default `\`
+		f.Args = append(f.Args, Raw("NULL"))
+		for _, p := range paths {
+			f.Args = append(f.Args, wrapRaw(p))
+		}
+	}
+	return
+}
+
+// JSONLength : the path is optional, and only one path is accepted, otherwise it returns error when it's built
+func JSONLength(doc interface{}, path ...string) (f primitive.JSONFunc) {
+	f.Type = primitive.JSON_LENGTH
+	f.Args = append(f.Args, wrapColumn(doc))
+	if len(path) > 1 {
+		f.Args = append(f.Args, primitive.Invalid{Err: errors.New("expr: JSON_LENGTH only accepts one path")})
+		return
+	}
+	for _, p := range path {
+		f.Args = append(f.Args, wrapRaw(p))
+	}
+	return
+}
+
+// JSONContainsPath : the `oneOrAll` can be either "one" or "all"
+func JSONContainsPath(doc interface{}, oneOrAll string, path string, paths ...string) (f primitive.JSONFunc) {
+	f.Type = primitive.JSON_CONTAINS_PATH
+	f.Args = append(f.Args, wrapColumn(doc), wrapRaw(oneOrAll))
+	for _, p := range append([]string{path}, paths...) {
+		f.Args = append(f.Args, wrapRaw(p))
+	}
+	return
+}
+
 // JSONColumn :
 func JSONColumn(column string, nested ...string) (c primitive.JSONColumn) {
 	c.Column = column
@@ -151,6 +211,23 @@ func JSONColumn(column string, nested ...string) (c primitive.JSONColumn) {
 	return
 }
 
+// wrapJSONValue : cast the value as json, so it can be compared with json document.
+// The value which cannot be marshalled is kept as `primitive.Invalid`, so the error is returned when it's built
+func wrapJSONValue(it interface{}) interface{} {
+	switch vi := it.(type) {
+	case primitive.Column, primitive.JSONColumn, primitive.JSONFunc, primitive.CastAs:
+		return vi
+	case json.RawMessage:
+		return primitive.CastAs{Value: primitive.Value{Raw: string(vi)}, DataType: primitive.JSON}
+	default:
+		b, err := json.Marshal(vi)
+		if err != nil {
+			return primitive.Invalid{Err: err}
+		}
+		return primitive.CastAs{Value: primitive.Value{Raw: string(b)}, DataType: primitive.JSON}
+	}
+}
+
 func wrapJSONColumn(it interface{}) interface{} {
 	switch vi := it.(type) {
 	case primitive.Column:
@@ -52,4 +52,53 @@ func TestJSON(t *testing.T) {
 			},
 		}, it)
 	})
+
+	t.Run("JSONContains", func(tst *testing.T) {
+		it = JSONContains("Tags", []string{"a", "b"}, "$.ids")
+		require.Equal(tst, primitive.JSONFunc{
+			Type: primitive.JSON_CONTAINS,
+			Args: []interface{}{
+				primitive.Column{Name: "Tags"},
+				primitive.CastAs{Value: primitive.Value{Raw: `["a","b"]`}, DataType: primitive.JSON},
+				primitive.Value{Raw: "$.ids"},
+			},
+		}, it)
+
+		it = JSONContains(Column("a"), json.RawMessage(`{"k":1}`))
+		require.Equal(tst, primitive.JSONFunc{
+			Type: primitive.JSON_CONTAINS,
+			Args: []interface{}{
+				primitive.Column{Name: "a"},
+				primitive.CastAs{Value: primitive.Value{Raw: `{"k":1}`}, DataType: primitive.JSON},
+			},
+		}, it)
+
+		it = JSONContains("Tags", make(chan int))
+		args := it.(primitive.JSONFunc).Args
+		require.IsType(tst, primitive.Invalid{}, args[1])
+		require.Error(tst, args[1].(primitive.Invalid).Err)
+	})
+
+	t.Run("JSONSearch", func(tst *testing.T) {
+		it = JSONSearch("Info", "one", "abc%", "$.name")
+		require.Equal(tst, primitive.JSONFunc{
+			Type: primitive.JSON_SEARCH,
+			Args: []interface{}{
+				primitive.Column{Name: "Info"},
+				primitive.Value{Raw: "one"},
+				primitive.Value{Raw: "abc%"},
+				primitive.Raw{Value: "NULL"},
+				primitive.Value{Raw: "$.name"},
+			},
+		}, it)
+	})
+
+	t.Run("JSONLength", func(tst *testing.T) {
+		f := JSONLength("Tags", "$.a")
+		require.Len(tst, f.Args, 2)
+
+		f = JSONLength("Tags", "$.a", "$.b")
+		require.Len(tst, f.Args, 2)
+		require.EqualError(tst, f.Args[1].(primitive.Invalid).Err, "expr: JSON_LENGTH only accepts one path")
+	})
 }
@@ -0,0 +1,87 @@
+package sql
+
+import (
+	"github.com/si3nloong/sqlike/sql/expr"
+)
+
+type jsonTableColumnType int
+
+// json table column types :
+const (
+	JSONTablePath jsonTableColumnType = iota + 1
+	JSONTableExistsPath
+	JSONTableOrdinality
+	JSONTableNested
+)
+
+// JSONTableStmt :
+type JSONTableStmt struct {
+	Doc     interface{}
+	Path    string
+	Columns []JSONTableColumn
+}
+
+// JSONTable : the doc can be a column name, `expr.Column` or json document, it must be aliased with `expr.As`
+//
+//	sql.Select().From(expr.As(sql.JSONTable("Items", "$[*]", sql.PathColumn("SKU", "VARCHAR(20)", "$.sku")), "i"))
+func JSONTable(doc interface{}, path string, columns ...JSONTableColumn) *JSONTableStmt {
+	if len(columns) < 1 {
+		panic("missing columns for JSON_TABLE")
+	}
+	stmt := new(JSONTableStmt)
+	switch vi := doc.(type) {
+	case string:
+		stmt.Doc = expr.Column(vi)
+	default:
+		stmt.Doc = vi
+	}
+	stmt.Path = path
+	stmt.Columns = columns
+	return stmt
+}
+
+// JSONTableColumn :
+type JSONTableColumn struct {
+	Type     jsonTableColumnType
+	Name     string
+	DataType string
+	Path     string
+	Columns  []JSONTableColumn
+	OnEmpty  *string
+	OnError  *string
+}
+
+// PathColumn : `name type PATH path`, the data type will be written as it is, eg. `VARCHAR(100)`
+func PathColumn(name, dataType, path string) JSONTableColumn {
+	return JSONTableColumn{Type: JSONTablePath, Name: name, DataType: dataType, Path: path}
+}
+
+// ExistsPathColumn : `name type EXISTS PATH path`, the value is 1 if the path exists, otherwise 0
+func ExistsPathColumn(name, dataType, path string) JSONTableColumn {
+	return JSONTableColumn{Type: JSONTableExistsPath, Name: name, DataType: dataType, Path: path}
+}
+
+// OrdinalityColumn : `name FOR ORDINALITY`
+func OrdinalityColumn(name string) JSONTableColumn {
+	return JSONTableColumn{Type: JSONTableOrdinality, Name: name}
+}
+
+// NestedPath : `NESTED PATH path COLUMNS (...)`, flatten the nested array into the rows of parent
+func NestedPath(path string, columns ...JSONTableColumn) JSONTableColumn {
+	if len(columns) < 1 {
+		panic("missing columns for NESTED PATH")
+	}
+	return JSONTableColumn{Type: JSONTableNested, Path: path, Columns: columns}
+}
+
+// DefaultOnEmpty : `DEFAULT value ON EMPTY`, the value must be a json string
+func (c JSONTableColumn) DefaultOnEmpty(value string) JSONTableColumn {
+	c.OnEmpty = &value
+	return c
+}
+
+// DefaultOnError : `DEFAULT value ON ERROR`, the value must be a json string
+func (c JSONTableColumn) DefaultOnError(value string) JSONTableColumn {
+	c.OnError = &value
+	return c
+}
@@ -1,6 +1,7 @@
 package sql
 
 import (
+	"errors"
 	"reflect"
 
 	"github.com/si3nloong/sqlike/reflext"
@@ -10,6 +11,8 @@ import (
 
 // SelectStmt :
 type SelectStmt struct {
+	Recursive   bool
+	CTEs        []primitive.CTE
 	DistinctOn  bool
 	Tables      []interface{}
 	Projections []interface{}
@@ -29,6 +32,37 @@ func Select(fields ...interface{}) *SelectStmt {
 	return stmt.Select(fields...)
 }
 
+// With : define a common table expression which can be used as table in `From` and joins
+func With(name string, query interface{}, columns ...string) *SelectStmt {
+	stmt := new(SelectStmt)
+	return stmt.With(name, query, columns...)
+}
+
+// WithRecursive :
+func WithRecursive(name string, query interface{}, columns ...string) *SelectStmt {
+	stmt := new(SelectStmt)
+	return stmt.WithRecursive(name, query, columns...)
+}
+
+// With : the statement will return error when it's built if the name is empty
+func (stmt *SelectStmt) With(name string, query interface{}, columns ...string) *SelectStmt {
+	if name == "" {
+		query = primitive.Invalid{Err: errors.New("sql: empty common table expression name")}
+	}
+	stmt.CTEs = append(stmt.CTEs, primitive.CTE{
+		Name:    name,
+		Columns: columns,
+		Query:   query,
+	})
+	return stmt
+}
+
+// WithRecursive : mark the `WITH` clause as `RECURSIVE`, it applies to all the common table expressions
+func (stmt *SelectStmt) WithRecursive(name string, query interface{}, columns ...string) *SelectStmt {
+	stmt.Recursive = true
+	return stmt.With(name, query, columns...)
+}
+
 // Select :
 func (stmt *SelectStmt) Select(fields ...interface{}) *SelectStmt {
 	if len(fields) == 1 {
@@ -48,7 +82,8 @@ func (stmt *SelectStmt) Select(fields ...interface{}) *SelectStmt {
 	return stmt
 }
 
-// From :
+// From : the table can be a table name, `expr.As` for alias or `*SelectStmt` for derived table.
+// The derived table is wrapped with parentheses, eg. `FROM (SELECT ...)`, previously it was written without parentheses.
 func (stmt *SelectStmt) From(values ...interface{}) *SelectStmt {
 	length := len(values)
 	if length == 0 {
@@ -78,6 +113,44 @@ func (stmt *SelectStmt) From(values ...interface{}) *SelectStmt {
 	return stmt
 }
 
+// InnerJoin : the table can be a string, `expr.As` for alias or `*SelectStmt` for derived table
+func (stmt *SelectStmt) InnerJoin(table interface{}) *SelectStmt {
+	stmt.Joins = append(stmt.Joins, primitive.Join{Type: primitive.InnerJoin, Table: table})
+	return stmt
+}
+
+// LeftJoin :
+func (stmt *SelectStmt) LeftJoin(table interface{}) *SelectStmt {
+	stmt.Joins = append(stmt.Joins, primitive.Join{Type: primitive.LeftJoin, Table: table})
+	return stmt
+}
+
+// RightJoin :
+func (stmt *SelectStmt) RightJoin(table interface{}) *SelectStmt {
+	stmt.Joins = append(stmt.Joins, primitive.Join{Type: primitive.RightJoin, Table: table})
+	return stmt
+}
+
+// CrossJoin :
+func (stmt *SelectStmt) CrossJoin(table interface{}) *SelectStmt {
+	stmt.Joins = append(stmt.Joins, primitive.Join{Type: primitive.CrossJoin, Table: table})
+	return stmt
+}
+
+// On : set the join conditions of the last joined table, the statement will return error when it's built if there is no joined table
+func (stmt *SelectStmt) On(fields ...interface{}) *SelectStmt {
+	length := len(stmt.Joins)
+	if length > 0 {
+		if j, ok := stmt.Joins[length-1].(primitive.Join); ok {
+			j.On = expr.And(fields...)
+			stmt.Joins[length-1] = j
+			return stmt
+		}
+	}
+	stmt.Joins = append(stmt.Joins, primitive.Invalid{Err: errors.New("sql: missing join table for on conditions")})
+	return stmt
+}
+
 // Distinct :
 func (stmt *SelectStmt) Distinct() *SelectStmt {
 	stmt.DistinctOn = true
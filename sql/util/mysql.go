@@ -20,11 +20,14 @@ func (util MySQLUtil) Quote(n string) string {
 	return "`" + n + "`"
 }
 
-// Wrap :
+// Wrap : backslash is an escape character within string literal (unless `NO_BACKSLASH_ESCAPES` is enabled),
+// so it's escaped as well as the single quote
 func (util MySQLUtil) Wrap(n string) string {
-	return "'" + n + "'"
+	return "'" + mysqlEscaper.Replace(n) + "'"
 }
 
+var mysqlEscaper = strings.NewReplacer(`\`, `\\`, "'", "''")
+
 // WrapOnlyValue :
 func (util MySQLUtil) WrapOnlyValue(n string) string {
 	// TODO: regex to check the string with () symbols
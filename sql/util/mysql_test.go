@@ -13,4 +13,7 @@ func TestMySQLUtil(t *testing.T) {
 	require.Equal(t, "?", utl.Var(1))
 	require.Equal(t, "?", utl.Var(10))
 	require.Equal(t, `'value'`, utl.Wrap("value"))
+	require.Equal(t, `'it''s'`, utl.Wrap("it's"))
+	require.Equal(t, `'a\\'`, utl.Wrap(`a\`))
+	require.Equal(t, `'a\\'' OR 1=1'`, utl.Wrap(`a\' OR 1=1`))
 }
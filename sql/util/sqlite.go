@@ -0,0 +1,37 @@
+package util
+
+import (
+	"strings"
+)
+
+// SQLiteUtil :
+type SQLiteUtil struct{}
+
+// TableName :
+func (util SQLiteUtil) TableName(db, table string) string {
+	return util.Quote(db) + "." + util.Quote(table)
+}
+
+// Var :
+func (util SQLiteUtil) Var(i int) string {
+	return "?"
+}
+
+// Quote : the double quote within identifier is escaped by doubling it
+func (util SQLiteUtil) Quote(n string) string {
+	return "\"" + strings.ReplaceAll(n, "\"", "\"\"") + "\""
+}
+
+// Wrap :
+func (util SQLiteUtil) Wrap(n string) string {
+	return "'" + strings.ReplaceAll(n, "'", "''") + "'"
+}
+
+// WrapOnlyValue :
+func (util SQLiteUtil) WrapOnlyValue(n string) string {
+	// TODO: regex to check the string with () symbols
+	if strings.Contains(n, "(") {
+		return n
+	}
+	return util.Wrap(n)
+}
@@ -0,0 +1,20 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteUtil(t *testing.T) {
+	utl := SQLiteUtil{}
+
+	require.Equal(t, `"abc"`, utl.Quote("abc"))
+	require.Equal(t, `"a""b"`, utl.Quote(`a"b`))
+	require.Equal(t, `"a\b"`, utl.Quote(`a\b`))
+	require.Equal(t, `"main"."table"`, utl.TableName("main", "table"))
+	require.Equal(t, "?", utl.Var(1))
+	require.Equal(t, `'it''s'`, utl.Wrap("it's"))
+	require.Equal(t, `(CURRENT_TIMESTAMP)`, utl.WrapOnlyValue("(CURRENT_TIMESTAMP)"))
+	require.Equal(t, `'abc'`, utl.WrapOnlyValue("abc"))
+}
@@ -0,0 +1,54 @@
+package util
+
+import "strings"
+
+// SplitStatements : split the sql script into statements by semicolon, semicolon within quotes will be ignored and line comments will be stripped
+func SplitStatements(script string) (stmts []string) {
+	var (
+		blr   strings.Builder
+		quote rune
+		runes = []rune(script)
+	)
+	flush := func() {
+		if v := strings.TrimSpace(blr.String()); v != "" {
+			stmts = append(stmts, v)
+		}
+		blr.Reset()
+	}
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		if quote != 0 {
+			blr.WriteRune(r)
+			switch r {
+			case '\\':
+				if i+1 < len(runes) {
+					i++
+					blr.WriteRune(runes[i])
+				}
+			case quote:
+				quote = 0
+			}
+			continue
+		}
+		switch r {
+		case '\'', '"', '`':
+			quote = r
+			blr.WriteRune(r)
+		case '-':
+			if i+1 < len(runes) && runes[i+1] == '-' {
+				for i < len(runes) && runes[i] != '\n' {
+					i++
+				}
+				blr.WriteByte('\n')
+				continue
+			}
+			blr.WriteRune(r)
+		case ';':
+			flush()
+		default:
+			blr.WriteRune(r)
+		}
+	}
+	flush()
+	return
+}
@@ -0,0 +1,20 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatements(t *testing.T) {
+	require.Empty(t, SplitStatements(""))
+	require.Empty(t, SplitStatements(" ; -- comment only\n;"))
+	require.Equal(t, []string{"SELECT 1"}, SplitStatements("SELECT 1"))
+	require.Equal(t, []string{
+		"CREATE TABLE `a` (`b` VARCHAR(10) COMMENT 'x;y')",
+		`CREATE INDEX "a_b" ON "a" ("b")`,
+		`INSERT INTO a VALUES ('it''s;', 'a\';b')`,
+	}, SplitStatements("CREATE TABLE `a` (`b` VARCHAR(10) COMMENT 'x;y');"+
+		"-- create index\n"+`CREATE INDEX "a_b" ON "a" ("b");`+"\n"+
+		`INSERT INTO a VALUES ('it''s;', 'a\';b');`))
+}
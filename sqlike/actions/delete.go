@@ -2,10 +2,14 @@ package actions
 
 import (
 	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
 )
 
 // DeleteStatement :
 type DeleteStatement interface {
+	InnerJoin(table interface{}) DeleteStatement
+	LeftJoin(table interface{}) DeleteStatement
+	On(fields ...interface{}) DeleteStatement
 	Where(fields ...interface{}) DeleteStatement
 	OrderBy(fields ...interface{}) DeleteStatement
 	Limit(num uint) DeleteStatement
@@ -15,11 +19,34 @@ type DeleteStatement interface {
 type DeleteActions struct {
 	Database   string
 	Table      string
+	Joins      []primitive.Join
 	Conditions []interface{}
 	Sorts      []interface{}
 	Record     uint
 }
 
+// InnerJoin : the table can be a string, `expr.As` for alias or `*sql.SelectStmt` for derived table
+func (act *DeleteActions) InnerJoin(table interface{}) DeleteStatement {
+	act.Joins = append(act.Joins, primitive.Join{Type: primitive.InnerJoin, Table: table})
+	return act
+}
+
+// LeftJoin :
+func (act *DeleteActions) LeftJoin(table interface{}) DeleteStatement {
+	act.Joins = append(act.Joins, primitive.Join{Type: primitive.LeftJoin, Table: table})
+	return act
+}
+
+// On : set the join conditions of the last joined table
+func (act *DeleteActions) On(fields ...interface{}) DeleteStatement {
+	length := len(act.Joins)
+	if length == 0 {
+		panic("missing join table for on conditions")
+	}
+	act.Joins[length-1].On = expr.And(fields...)
+	return act
+}
+
 // Where :
 func (act *DeleteActions) Where(fields ...interface{}) DeleteStatement {
 	act.Conditions = expr.And(fields...).Values
@@ -1,6 +1,7 @@
 package actions
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/si3nloong/sqlike/sql/expr"
@@ -12,6 +13,11 @@ type SelectStatement interface {
 	Distinct() SelectStatement
 	Select(fields ...interface{}) SelectStatement
 	From(values ...string) SelectStatement
+	InnerJoin(table interface{}) SelectStatement
+	LeftJoin(table interface{}) SelectStatement
+	RightJoin(table interface{}) SelectStatement
+	CrossJoin(table interface{}) SelectStatement
+	On(fields ...interface{}) SelectStatement
 	Where(fields ...interface{}) SelectStatement
 	Having(fields ...interface{}) SelectStatement
 	GroupBy(fields ...interface{}) SelectStatement
@@ -27,6 +33,7 @@ type FindActions struct {
 	Table       string
 	Projections []interface{}
 	IndexHints  string
+	Joins       []primitive.Join
 	Conditions  primitive.Group
 	Havings     primitive.Group
 	GroupBys    []interface{}
@@ -69,6 +76,43 @@ func (act *FindActions) From(values ...string) SelectStatement {
 	return act
 }
 
+// InnerJoin : the table can be a string, `expr.As` for alias or `*sql.SelectStmt` for derived table
+func (act *FindActions) InnerJoin(table interface{}) SelectStatement {
+	act.Joins = append(act.Joins, primitive.Join{Type: primitive.InnerJoin, Table: table})
+	return act
+}
+
+// LeftJoin :
+func (act *FindActions) LeftJoin(table interface{}) SelectStatement {
+	act.Joins = append(act.Joins, primitive.Join{Type: primitive.LeftJoin, Table: table})
+	return act
+}
+
+// RightJoin :
+func (act *FindActions) RightJoin(table interface{}) SelectStatement {
+	act.Joins = append(act.Joins, primitive.Join{Type: primitive.RightJoin, Table: table})
+	return act
+}
+
+// CrossJoin :
+func (act *FindActions) CrossJoin(table interface{}) SelectStatement {
+	act.Joins = append(act.Joins, primitive.Join{Type: primitive.CrossJoin, Table: table})
+	return act
+}
+
+// On : set the join conditions of the last joined table, the action will return error when it's built if there is no joined table
+func (act *FindActions) On(fields ...interface{}) SelectStatement {
+	length := len(act.Joins)
+	if length == 0 {
+		act.Joins = append(act.Joins, primitive.Join{
+			Table: primitive.Invalid{Err: errors.New("actions: missing join table for on conditions")},
+		})
+		return act
+	}
+	act.Joins[length-1].On = expr.And(fields...)
+	return act
+}
+
 // Where :
 func (act *FindActions) Where(fields ...interface{}) SelectStatement {
 	act.Conditions = expr.And(fields...)
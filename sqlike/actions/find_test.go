@@ -3,6 +3,8 @@ package actions
 import (
 	"testing"
 
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
 	"github.com/stretchr/testify/require"
 )
 
@@ -17,3 +19,23 @@ func TestFindActions(t *testing.T) {
 	require.Equal(t, "db", act.Database)
 	require.Equal(t, "table", act.Table)
 }
+
+func TestFindActionsJoin(t *testing.T) {
+	act := Find().
+		From("db", "User").
+		InnerJoin("Order").
+		On(expr.Equal(expr.Column("Order", "UserID"), expr.Column("User", "ID"))).
+		CrossJoin("Country").(*FindActions)
+
+	require.Equal(t, 2, len(act.Joins))
+	require.Equal(t, primitive.InnerJoin, act.Joins[0].Type)
+	require.Equal(t, "Order", act.Joins[0].Table)
+	require.Equal(t, 1, len(act.Joins[0].On.Values))
+	require.Equal(t, primitive.CrossJoin, act.Joins[1].Type)
+	require.Empty(t, act.Joins[1].On.Values)
+
+	// the missing join table is recorded as invalid table
+	act = Find().From("User").On(expr.Equal("A", 1)).(*FindActions)
+	require.Equal(t, 1, len(act.Joins))
+	require.IsType(t, primitive.Invalid{}, act.Joins[0].Table)
+}
@@ -7,6 +7,9 @@ import (
 
 // UpdateStatement :
 type UpdateStatement interface {
+	InnerJoin(table interface{}) UpdateStatement
+	LeftJoin(table interface{}) UpdateStatement
+	On(fields ...interface{}) UpdateStatement
 	Where(fields ...interface{}) UpdateStatement
 	Set(values ...primitive.KV) UpdateStatement
 	OrderBy(fields ...interface{}) UpdateStatement
@@ -17,12 +20,35 @@ type UpdateStatement interface {
 type UpdateActions struct {
 	Database   string
 	Table      string
+	Joins      []primitive.Join
 	Conditions []interface{}
 	Values     []primitive.KV
 	Sorts      []interface{}
 	Record     uint
 }
 
+// InnerJoin : the table can be a string, `expr.As` for alias or `*sql.SelectStmt` for derived table
+func (act *UpdateActions) InnerJoin(table interface{}) UpdateStatement {
+	act.Joins = append(act.Joins, primitive.Join{Type: primitive.InnerJoin, Table: table})
+	return act
+}
+
+// LeftJoin :
+func (act *UpdateActions) LeftJoin(table interface{}) UpdateStatement {
+	act.Joins = append(act.Joins, primitive.Join{Type: primitive.LeftJoin, Table: table})
+	return act
+}
+
+// On : set the join conditions of the last joined table
+func (act *UpdateActions) On(fields ...interface{}) UpdateStatement {
+	length := len(act.Joins)
+	if length == 0 {
+		panic("missing join table for on conditions")
+	}
+	act.Joins[length-1].On = expr.And(fields...)
+	return act
+}
+
 // Where :
 func (act *UpdateActions) Where(fields ...interface{}) UpdateStatement {
 	act.Conditions = expr.And(fields...).Values
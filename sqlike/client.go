@@ -52,6 +52,10 @@ type Client struct {
 	cache   reflext.StructMapper
 	codec   codec.Codecer
 	dialect dialect.Dialect
+	// read replicas, it will be nil if there is no replica
+	replicas *replicaSet
+	// handler of the error returned or panic recovered from transaction callbacks
+	txCallbackErrorHandler func(ctx context.Context, err error)
 }
 
 // newClient : create a new client struct by providing driver, *sql.DB, dialect etc
@@ -81,6 +85,13 @@ func (c *Client) SetLogger(logger logs.Logger) *Client {
 	return c
 }
 
+// SetTxCallbackErrorHandler : the error returned and the panic recovered from `OnCommit` and `OnRollback` callbacks will be reported to the handler,
+// it will be logged if there is no handler
+func (c *Client) SetTxCallbackErrorHandler(handler func(ctx context.Context, err error)) *Client {
+	c.txCallbackErrorHandler = handler
+	return c
+}
+
 // SetPrimaryKey : this will set a default primary key for subsequent operation such as Insert, InsertOne, ModifyOne
 func (c *Client) SetPrimaryKey(pk string) *Client {
 	c.pk = pk
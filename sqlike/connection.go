@@ -10,14 +10,20 @@ import (
 	"github.com/si3nloong/sqlike/sql/dialect"
 	sqldialect "github.com/si3nloong/sqlike/sql/dialect"
 	"github.com/si3nloong/sqlike/sql/dialect/mysql"
+	"github.com/si3nloong/sqlike/sql/dialect/postgres"
+	"github.com/si3nloong/sqlike/sql/dialect/sqlite"
 	"github.com/si3nloong/sqlike/sqlike/options"
 )
 
 func init() {
 	dialect.RegisterDialect("mysql", mysql.New())
+	dialect.RegisterDialect("postgres", postgres.New())
+	// `mattn/go-sqlite3` register as "sqlite3", `modernc.org/sqlite` register as "sqlite"
+	dialect.RegisterDialect("sqlite3", sqlite.New())
+	dialect.RegisterDialect("sqlite", sqlite.New())
 }
 
-// Open : open connection to sql server with connection string
+// Open : open connection to sql server with connection string, reads will be routed to the replicas if there is any
 func Open(ctx context.Context, driver string, opt *options.ConnectOptions) (client *Client, err error) {
 	if opt == nil {
 		return nil, errors.New("sqlike: invalid connection options <nil>")
@@ -31,6 +37,24 @@ func Open(ctx context.Context, driver string, opt *options.ConnectOptions) (clie
 		return
 	}
 	client, err = newClient(ctx, driver, db, dialect, opt.Charset, opt.Collate)
+	if err != nil {
+		return
+	}
+	if len(opt.Replicas) > 0 {
+		dbs := make([]*sql.DB, 0, len(opt.Replicas))
+		for _, r := range opt.Replicas {
+			rdb, err := sql.Open(driver, dialect.Connect(r))
+			if err != nil {
+				for _, rdb := range dbs {
+					rdb.Close()
+				}
+				db.Close()
+				return nil, err
+			}
+			dbs = append(dbs, rdb)
+		}
+		client.replicas = newReplicaSet(dbs, opt.ReplicaPolicy, opt.HealthCheckInterval)
+	}
 	return
 }
 
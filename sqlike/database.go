@@ -56,6 +56,7 @@ func (db *Database) Table(name string) *Table {
 
 func (db *Database) QueryRow(ctx context.Context, query string, args ...interface{}) SingleResult {
 	rslt := new(Result)
+	rslt.ctx = ctx
 	rslt.cache = db.client.cache
 	rslt.codec = db.codec
 	rows, err := db.driver.QueryContext(ctx, query, args...)
@@ -78,7 +79,7 @@ func (db *Database) QueryRow(ctx context.Context, query string, args ...interfac
 	return rslt
 }
 
-// QueryStmt :
+// QueryStmt : the query will be routed to replica if there is any, use `WithPrimary` to read from primary
 func (db *Database) QueryStmt(ctx context.Context, query interface{}) (*Result, error) {
 	if query == nil {
 		return nil, errors.New("sqlike: empty query statement")
@@ -92,7 +93,7 @@ func (db *Database) QueryStmt(ctx context.Context, query interface{}) (*Result,
 
 	rows, err := driver.Query(
 		ctx,
-		db.driver,
+		db.client.reader(ctx, executor(ctx, db.client, db.driver), false),
 		stmt,
 		getLogger(db.logger, true),
 	)
@@ -101,6 +102,7 @@ func (db *Database) QueryStmt(ctx context.Context, query interface{}) (*Result,
 	}
 
 	rslt := new(Result)
+	rslt.ctx = ctx
 	rslt.cache = db.client.cache
 	rslt.codec = db.codec
 	rslt.rows = rows
@@ -128,8 +130,7 @@ func (db *Database) beginTrans(ctx context.Context, opt *sql.TxOptions) (*Transa
 	if err != nil {
 		return nil, err
 	}
-	return &Transaction{
-		Context: ctx,
+	t := &Transaction{
 		dbName:  db.name,
 		pk:      db.pk,
 		client:  db.client,
@@ -137,15 +138,42 @@ func (db *Database) beginTrans(ctx context.Context, opt *sql.TxOptions) (*Transa
 		dialect: db.dialect,
 		logger:  db.logger,
 		codec:   db.codec,
-	}, nil
+	}
+	// the context of transaction carries itself, so the context derived from the transaction is able to join it
+	t.Context = WithTransaction(ctx, t)
+	return t, nil
 }
 
-// RunInTransaction :
+// RunInTransaction : the callback will be re-run in a new transaction when the transaction failed with a retryable error
+// (deadlock, serialization failure or lock wait timeout) and the retry is enabled by `options.TransactionOptions.SetRetry`.
+// It joins the transaction in context as nested transaction, the timeout, isolation level, read only and retry are
+// decided by the outermost transaction, so it returns `ErrNestedTransactionOptions` if any of them is set.
 func (db *Database) RunInTransaction(ctx context.Context, cb txCallback, opts ...*options.TransactionOptions) error {
 	opt := new(options.TransactionOptions)
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
+	if tx, ok := activeTransaction(ctx, db.client); ok {
+		if opt.Duration > 0 || opt.IsolationLevel != options.LevelDefault || opt.ReadOnly || opt.RetryAttempts > 1 {
+			return ErrNestedTransactionOptions
+		}
+		return tx.RunInTransaction(cb)
+	}
+	for retry := uint(0); ; retry++ {
+		err := db.runInTransaction(withRetryCount(ctx, retry), cb, opt)
+		if err == nil || retry+1 >= opt.RetryAttempts || !db.dialect.IsRetryable(err) {
+			return err
+		}
+		if opt.OnRetry != nil {
+			opt.OnRetry(ctx, retry+1, err)
+		}
+		if err := sleepContext(ctx, retryDelay(opt.RetryBackoff, retry+1)); err != nil {
+			return err
+		}
+	}
+}
+
+func (db *Database) runInTransaction(ctx context.Context, cb txCallback, opt *options.TransactionOptions) error {
 	duration := 60 * time.Second
 	if opt.Duration.Seconds() > 0 {
 		duration = opt.Duration
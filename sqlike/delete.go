@@ -20,18 +20,19 @@ func (tb *Table) DestroyOne(ctx context.Context, delete interface{}, opts ...*op
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
-	return destroyOne(
+	err := destroyOne(
 		ctx,
 		tb.dbName,
 		tb.name,
 		tb.pk,
 		tb.client.cache,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
 		tb.logger,
 		delete,
 		opt,
 	)
+	return tb.dialect.MapError(err)
 }
 
 // DeleteOne : delete single record on the table using where clause.
@@ -45,16 +46,17 @@ func (tb *Table) DeleteOne(ctx context.Context, act actions.DeleteOneStatement,
 		opt = opts[0]
 	}
 	x.Limit(1)
-	return deleteMany(
+	affected, err := deleteMany(
 		ctx,
 		tb.dbName,
 		tb.name,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
 		tb.logger,
 		&x.DeleteActions,
 		&opt.DeleteOptions,
 	)
+	return affected, tb.dialect.MapError(err)
 }
 
 // Delete : delete multiple record on the table using where clause. If you didn't provided any where clause, it will throw error. For multiple record deletion without where clause, you should use `Truncate` instead.
@@ -67,16 +69,17 @@ func (tb *Table) Delete(ctx context.Context, act actions.DeleteStatement, opts .
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
-	return deleteMany(
+	affected, err := deleteMany(
 		ctx,
 		tb.dbName,
 		tb.name,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
 		tb.logger,
 		x,
 		opt,
 	)
+	return affected, tb.dialect.MapError(err)
 }
 
 func deleteMany(ctx context.Context, dbName, tbName string, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, act *actions.DeleteActions, opt *options.DeleteOptions) (int64, error) {
@@ -113,6 +116,10 @@ func destroyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.St
 		return ErrInvalidInput
 	}
 
+	if err := invokeHook(ctx, beforeDelete, v); err != nil {
+		return err
+	}
+
 	t := v.Type()
 	cdc := cache.CodecByType(t)
 	x := new(actions.DeleteActions)
@@ -158,5 +165,5 @@ func destroyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.St
 	if affected, _ := result.RowsAffected(); affected <= 0 {
 		return errors.New("sqlike: unable to delete entity")
 	}
-	return err
+	return invokeHook(ctx, afterDelete, v)
 }
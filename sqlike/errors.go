@@ -13,4 +13,8 @@ var (
 	ErrNilEntity = errors.New("sqlike: entity is <nil>")
 	// ErrNoColumn :
 	ErrNoColumn = errors.New("sqlike: no columns to create index")
+	// ErrForeignKeyColumn :
+	ErrForeignKeyColumn = errors.New("sqlike: foreign key columns must match the referenced columns")
+	// ErrNestedTransactionOptions :
+	ErrNestedTransactionOptions = errors.New("sqlike: transaction options cannot be applied on nested transaction")
 )
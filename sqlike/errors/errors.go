@@ -0,0 +1,56 @@
+// Package errors is the portable errors of database, the dialect maps its error code to the error,
+// so the caller is able to check the error using `errors.Is` and `errors.As` instead of matching the driver error.
+package errors
+
+import "errors"
+
+// errors : portable database errors
+var (
+	// ErrDuplicateKey : the value violates unique index (or primary key)
+	ErrDuplicateKey = errors.New("sqlike: duplicate key")
+	// ErrForeignKeyViolation : the value violates foreign key constraint
+	ErrForeignKeyViolation = errors.New("sqlike: foreign key violation")
+	// ErrDeadlock : the transaction is rolled back due to deadlock
+	ErrDeadlock = errors.New("sqlike: deadlock")
+	// ErrLockWaitTimeout : the lock is not acquired within the timeout
+	ErrLockWaitTimeout = errors.New("sqlike: lock wait timeout")
+	// ErrDataTooLong : the value exceeds the size of column
+	ErrDataTooLong = errors.New("sqlike: data too long")
+	// ErrTableNotFound : the table doesn't exist
+	ErrTableNotFound = errors.New("sqlike: table not found")
+)
+
+// Error : the portable error which wraps the original error of driver, it matches both the portable error and the original error.
+type Error struct {
+	// the portable error, eg. `ErrDuplicateKey`
+	Kind error
+	// the offending index (or constraint) name, it's only available on `ErrDuplicateKey` and `ErrForeignKeyViolation`,
+	// and it may be empty if the driver doesn't report it
+	Index string
+	// the original error of driver
+	Err error
+}
+
+// New : wrap the original error with the portable error
+func New(kind error, err error) *Error {
+	return &Error{Kind: kind, Err: err}
+}
+
+// WithIndex : set the offending index name
+func (e *Error) WithIndex(name string) *Error {
+	e.Index = name
+	return e
+}
+
+func (e *Error) Error() string {
+	msg := e.Kind.Error()
+	if e.Index != "" {
+		msg += " (" + e.Index + ")"
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+// Unwrap :
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestError(t *testing.T) {
+	cause := errors.New("Duplicate entry 'a' for key 'Email'")
+	err := error(New(ErrDuplicateKey, cause).WithIndex("Email"))
+
+	require.True(t, errors.Is(err, ErrDuplicateKey))
+	require.True(t, errors.Is(err, cause))
+	require.False(t, errors.Is(err, ErrDeadlock))
+	require.EqualError(t, err, "sqlike: duplicate key (Email): Duplicate entry 'a' for key 'Email'")
+
+	var e *Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, "Email", e.Index)
+
+	require.EqualError(t, New(ErrDeadlock, cause), "sqlike: deadlock: Duplicate entry 'a' for key 'Email'")
+}
@@ -0,0 +1,39 @@
+package sqlike
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	sqlerrors "github.com/si3nloong/sqlike/sqlike/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMapError(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	dup := &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry 'a@b.c' for key 'users.Email'"}
+	r.ExecErr = dup
+
+	_, err := db.Table("users").UpdateOne(
+		ctx,
+		actions.UpdateOne().
+			Where(expr.Equal("ID", 1)).
+			Set(expr.ColumnValue("Email", "a@b.c")),
+	)
+	require.True(t, errors.Is(err, sqlerrors.ErrDuplicateKey))
+	var e *sqlerrors.Error
+	require.True(t, errors.As(err, &e))
+	require.Equal(t, "Email", e.Index)
+	// the original error is still accessible
+	var me *gomysql.MySQLError
+	require.True(t, errors.As(err, &me))
+	require.Equal(t, dup, me)
+
+	// the error of sqlike is returned as it is
+	_, err = db.Table("users").UpdateOne(ctx, actions.UpdateOne())
+	require.Equal(t, ErrNoValueUpdate, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 
+	semver "github.com/Masterminds/semver/v3"
 	"github.com/si3nloong/sqlike/reflext"
 	"github.com/si3nloong/sqlike/sql/codec"
 	sqldialect "github.com/si3nloong/sqlike/sql/dialect"
@@ -40,8 +41,9 @@ func (tb *Table) FindOne(ctx context.Context, act actions.SelectOneStatement, op
 		tb.name,
 		tb.client.cache,
 		tb.codec,
-		tb.driver,
+		tb.reader(ctx, &opt.FindOptions),
 		tb.dialect,
+		tb.client.version,
 		tb.logger,
 		&x.FindActions,
 		&opt.FindOptions,
@@ -77,8 +79,9 @@ func (tb *Table) Find(ctx context.Context, act actions.SelectStatement, opts ...
 		tb.name,
 		tb.client.cache,
 		tb.codec,
-		tb.driver,
+		tb.reader(ctx, opt),
 		tb.dialect,
+		tb.client.version,
 		tb.logger,
 		x,
 		opt,
@@ -90,7 +93,12 @@ func (tb *Table) Find(ctx context.Context, act actions.SelectStatement, opts ...
 	return csr, nil
 }
 
-func find(ctx context.Context, dbName, tbName string, cache reflext.StructMapper, cdc codec.Codecer, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, act *actions.FindActions, opt *options.FindOptions, lock options.LockMode) *Result {
+// reader : locking read always goes to primary
+func (tb *Table) reader(ctx context.Context, opt *options.FindOptions) sqldriver.Driver {
+	return tb.client.reader(ctx, tb.executor(ctx), opt.Primary || opt.LockMode != options.NoLock)
+}
+
+func find(ctx context.Context, dbName, tbName string, cache reflext.StructMapper, cdc codec.Codecer, driver sqldriver.Driver, dialect sqldialect.Dialect, version *semver.Version, logger logs.Logger, act *actions.FindActions, opt *options.FindOptions, lock options.LockMode) *Result {
 	if act.Database == "" {
 		act.Database = dbName
 	}
@@ -98,12 +106,17 @@ func find(ctx context.Context, dbName, tbName string, cache reflext.StructMapper
 		act.Table = tbName
 	}
 	rslt := new(Result)
+	rslt.ctx = ctx
 	rslt.cache = cache
 	rslt.codec = cdc
 
 	stmt := sqlstmt.AcquireStmt(dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
-	if err := dialect.Select(stmt, act, lock); err != nil {
+	if err := dialect.ValidateLock(version, lock, opt.LockOf); err != nil {
+		rslt.err = err
+		return rslt
+	}
+	if err := dialect.Select(stmt, act, lock, opt.LockOf...); err != nil {
 		rslt.err = err
 		return rslt
 	}
@@ -0,0 +1,112 @@
+package sqlike
+
+import (
+	"context"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+	sqldriver "github.com/si3nloong/sqlike/sql/driver"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+)
+
+// ForeignKey :
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnDelete   string
+	OnUpdate   string
+}
+
+// ForeignKeyView :
+type ForeignKeyView struct {
+	tb *Table
+}
+
+// List :
+func (fkv *ForeignKeyView) List(ctx context.Context) ([]ForeignKey, error) {
+	return fkv.tb.ListForeignKeys(ctx)
+}
+
+// CreateOne :
+func (fkv *ForeignKeyView) CreateOne(ctx context.Context, fk foreignkeys.ForeignKey) error {
+	return fkv.Create(ctx, []foreignkeys.ForeignKey{fk})
+}
+
+// Create :
+func (fkv *ForeignKeyView) Create(ctx context.Context, fks []foreignkeys.ForeignKey) error {
+	for _, fk := range fks {
+		if len(fk.Columns) < 1 || len(fk.Columns) != len(fk.RefColumns) {
+			return ErrForeignKeyColumn
+		}
+	}
+	stmt := sqlstmt.AcquireStmt(fkv.tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	if err := fkv.tb.dialect.CreateForeignKeys(stmt, fkv.tb.dbName, fkv.tb.name, fks); err != nil {
+		return err
+	}
+	_, err := sqldriver.Execute(
+		ctx,
+		fkv.tb.executor(ctx),
+		stmt,
+		fkv.tb.logger,
+	)
+	return err
+}
+
+// DropOne :
+func (fkv *ForeignKeyView) DropOne(ctx context.Context, name string) error {
+	stmt := sqlstmt.AcquireStmt(fkv.tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	if err := fkv.tb.dialect.DropForeignKeys(stmt, fkv.tb.dbName, fkv.tb.name, []string{name}); err != nil {
+		return err
+	}
+	_, err := sqldriver.Execute(
+		ctx,
+		fkv.tb.executor(ctx),
+		stmt,
+		fkv.tb.logger,
+	)
+	return err
+}
+
+// foreignKeyNames : the names of existing foreign keys to compare with the declared foreign keys on migration.
+// Not every database keeps the constraint name (eg. sqlite), so the unmanaged foreign key which has the same definition
+// as the declared one is named after the declared one.
+func foreignKeyNames(table string, fields []reflext.StructFielder, existing []ForeignKey) ([]string, error) {
+	declared, err := foreignkeys.FromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(existing))
+	for i, fk := range existing {
+		names[i] = fk.Name
+		if strings.HasPrefix(fk.Name, foreignkeys.Prefix) {
+			continue
+		}
+		for _, d := range declared {
+			if fk.sameAs(d) {
+				names[i] = d.GetName(table)
+				break
+			}
+		}
+	}
+	return names, nil
+}
+
+// sameAs : the referential action is `NO ACTION` if it's not specified
+func (fk ForeignKey) sameAs(d foreignkeys.ForeignKey) bool {
+	action := func(v string) string {
+		if v == "" {
+			return string(foreignkeys.NoAction)
+		}
+		return strings.ToUpper(v)
+	}
+	return strings.EqualFold(fk.RefTable, d.RefTable) &&
+		strings.Join(fk.Columns, ",") == strings.Join(d.Columns, ",") &&
+		strings.Join(fk.RefColumns, ",") == strings.Join(d.RefColumns, ",") &&
+		action(fk.OnDelete) == action(string(d.OnDelete)) &&
+		action(fk.OnUpdate) == action(string(d.OnUpdate))
+}
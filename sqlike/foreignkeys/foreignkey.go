@@ -0,0 +1,132 @@
+package foreignkeys
+
+import (
+	"crypto/md5"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+)
+
+// Action : referential action of `ON DELETE` and `ON UPDATE`
+type Action string
+
+// actions :
+const (
+	NoAction   Action = "NO ACTION"
+	Restrict   Action = "RESTRICT"
+	Cascade    Action = "CASCADE"
+	SetNull    Action = "SET NULL"
+	SetDefault Action = "SET DEFAULT"
+)
+
+// Prefix : the name prefix of the foreign key which is managed by migration
+const Prefix = "FK_"
+
+// ForeignKey :
+type ForeignKey struct {
+	Name       string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+	OnDelete   Action
+	OnUpdate   Action
+}
+
+// GetName : the foreign key name must be unique within the database (mysql), so the table name is part of the hash name
+func (fk ForeignKey) GetName(table string) string {
+	if fk.Name != "" {
+		return fk.Name
+	}
+	return fk.HashName(table)
+}
+
+// HashName :
+func (fk ForeignKey) HashName(table string) string {
+	hash := md5.New()
+	hash.Write([]byte(table + "(" + strings.Join(fk.Columns, ";") + ")"))
+	hash.Write([]byte("->" + fk.RefTable + "(" + strings.Join(fk.RefColumns, ";") + ")"))
+	hash.Write([]byte("@" + string(fk.OnDelete) + ";" + string(fk.OnUpdate)))
+	return Prefix + fmt.Sprintf("%x", hash.Sum(nil))
+}
+
+// ParseAction : parse the action from tag value, such as `cascade`, `set_null` or `no action`
+func ParseAction(v string) (Action, error) {
+	act := Action(strings.ToUpper(strings.Join(strings.Fields(strings.ReplaceAll(v, "_", " ")), " ")))
+	switch act {
+	case NoAction, Restrict, Cascade, SetNull, SetDefault:
+		return act, nil
+	}
+	return "", fmt.Errorf("foreignkeys: invalid referential action %q", v)
+}
+
+// FromField : build the foreign key from the field tag, eg. `sqlike:",foreign_key=users.id,on_delete=cascade,on_update=restrict"`.
+// It returns false if the field doesn't have `foreign_key` tag.
+func FromField(sf reflext.StructFielder) (fk ForeignKey, ok bool, err error) {
+	tag := sf.Tag()
+	ref, ok := tag.LookUp("foreign_key")
+	if !ok {
+		return
+	}
+	paths := strings.Split(ref, ".")
+	if len(paths) != 2 || paths[0] == "" || paths[1] == "" {
+		err = errors.New("foreignkeys: foreign key reference must be in format `table.column`")
+		return
+	}
+	fk.Columns = []string{sf.Name()}
+	fk.RefTable = paths[0]
+	fk.RefColumns = []string{paths[1]}
+	if v, exists := tag.LookUp("on_delete"); exists {
+		if fk.OnDelete, err = ParseAction(v); err != nil {
+			return
+		}
+	}
+	if v, exists := tag.LookUp("on_update"); exists {
+		if fk.OnUpdate, err = ParseAction(v); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// FromFields : collect the foreign keys from the field tags
+func FromFields(fields []reflext.StructFielder) ([]ForeignKey, error) {
+	fks := make([]ForeignKey, 0)
+	for _, sf := range fields {
+		fk, ok, err := FromField(sf)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			fks = append(fks, fk)
+		}
+	}
+	return fks, nil
+}
+
+// Diff : compare the foreign keys with the existing foreign key names of the table, it returns the foreign keys to add and the names to drop.
+// The foreign key which is created by migration will be dropped once it's removed from the struct, others will only be dropped if unsafe.
+func Diff(table string, fks []ForeignKey, existing []string, unsafe bool) (adds []ForeignKey, drops []string) {
+	names := make(map[string]bool, len(existing))
+	for _, name := range existing {
+		names[name] = true
+	}
+	for _, fk := range fks {
+		name := fk.GetName(table)
+		if names[name] {
+			delete(names, name)
+			continue
+		}
+		adds = append(adds, fk)
+	}
+	for _, name := range existing {
+		if !names[name] {
+			continue
+		}
+		if unsafe || strings.HasPrefix(name, Prefix) {
+			drops = append(drops, name)
+		}
+	}
+	return
+}
@@ -0,0 +1,69 @@
+package foreignkeys
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/stretchr/testify/require"
+)
+
+type post struct {
+	ID     int64
+	UserID int64 `sqlike:",foreign_key=users.ID,on_delete=cascade,on_update=set_null"`
+	Title  string
+}
+
+func TestForeignKey(t *testing.T) {
+	t.Run("ParseAction", func(ti *testing.T) {
+		for v, act := range map[string]Action{
+			"cascade":     Cascade,
+			"RESTRICT":    Restrict,
+			"set_null":    SetNull,
+			"set default": SetDefault,
+			"no_action":   NoAction,
+		} {
+			result, err := ParseAction(v)
+			require.NoError(ti, err)
+			require.Equal(ti, act, result)
+		}
+		_, err := ParseAction("drop")
+		require.Error(ti, err)
+	})
+
+	fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(post{})).Properties()
+	fks, err := FromFields(fields)
+	require.NoError(t, err)
+	require.Equal(t, []ForeignKey{{
+		Columns:    []string{"UserID"},
+		RefTable:   "users",
+		RefColumns: []string{"ID"},
+		OnDelete:   Cascade,
+		OnUpdate:   SetNull,
+	}}, fks)
+
+	name := fks[0].GetName("posts")
+	require.True(t, strings.HasPrefix(name, Prefix))
+	require.Equal(t, name, fks[0].HashName("posts"))
+	require.NotEqual(t, name, fks[0].GetName("comments"))
+	require.Equal(t, "fk_user", ForeignKey{Name: "fk_user"}.GetName("posts"))
+
+	t.Run("Invalid", func(ti *testing.T) {
+		type invalid struct {
+			UserID int64 `sqlike:",foreign_key=users"`
+		}
+		_, err := FromFields(reflext.DefaultMapper.CodecByType(reflect.TypeOf(invalid{})).Properties())
+		require.Error(ti, err)
+	})
+
+	t.Run("Diff", func(ti *testing.T) {
+		adds, drops := Diff("posts", fks, []string{name, "FK_legacy", "custom"}, false)
+		require.Empty(ti, adds)
+		require.Equal(ti, []string{"FK_legacy"}, drops)
+
+		adds, drops = Diff("posts", fks, []string{"custom"}, true)
+		require.Equal(ti, fks, adds)
+		require.Equal(ti, []string{"custom"}, drops)
+	})
+}
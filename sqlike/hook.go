@@ -0,0 +1,114 @@
+package sqlike
+
+import (
+	"context"
+	"reflect"
+)
+
+// BeforeInserter : entity which implements `BeforeInsert` will be invoked before it's inserted, returning error will abort the operation
+type BeforeInserter interface {
+	BeforeInsert(ctx context.Context) error
+}
+
+// AfterInserter : entity which implements `AfterInsert` will be invoked after it's inserted. The insert is already committed
+// when it's invoked unless the operation is running within a transaction, so returning error won't undo it. Run the operation
+// within `RunInTransaction` and return the error from the callback if the insert should be rolled back together.
+type AfterInserter interface {
+	AfterInsert(ctx context.Context) error
+}
+
+// BeforeUpdater : entity which implements `BeforeUpdate` will be invoked before it's modified, returning error will abort the operation
+type BeforeUpdater interface {
+	BeforeUpdate(ctx context.Context) error
+}
+
+// AfterUpdater : entity which implements `AfterUpdate` will be invoked after it's modified. Same as `AfterInserter`,
+// the update is already committed unless the operation is running within a transaction.
+type AfterUpdater interface {
+	AfterUpdate(ctx context.Context) error
+}
+
+// BeforeDeleter : entity which implements `BeforeDelete` will be invoked before it's destroyed, returning error will abort the operation
+type BeforeDeleter interface {
+	BeforeDelete(ctx context.Context) error
+}
+
+// AfterDeleter : entity which implements `AfterDelete` will be invoked after it's destroyed. Same as `AfterInserter`,
+// the delete is already committed unless the operation is running within a transaction.
+type AfterDeleter interface {
+	AfterDelete(ctx context.Context) error
+}
+
+// AfterLoader : entity which implements `AfterLoad` will be invoked after it's decoded from the result
+type AfterLoader interface {
+	AfterLoad(ctx context.Context) error
+}
+
+type hook int
+
+// hooks :
+const (
+	beforeInsert hook = iota
+	afterInsert
+	beforeUpdate
+	afterUpdate
+	beforeDelete
+	afterDelete
+	afterLoad
+)
+
+// invokeHook : the entity will be addressed whenever it's possible, so pointer receiver methods will be invoked as well
+func invokeHook(ctx context.Context, h hook, v reflect.Value) error {
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return nil
+	}
+	if v.Kind() != reflect.Ptr && v.CanAddr() {
+		v = v.Addr()
+	}
+
+	it := v.Interface()
+	switch h {
+	case beforeInsert:
+		if x, ok := it.(BeforeInserter); ok {
+			return x.BeforeInsert(ctx)
+		}
+	case afterInsert:
+		if x, ok := it.(AfterInserter); ok {
+			return x.AfterInsert(ctx)
+		}
+	case beforeUpdate:
+		if x, ok := it.(BeforeUpdater); ok {
+			return x.BeforeUpdate(ctx)
+		}
+	case afterUpdate:
+		if x, ok := it.(AfterUpdater); ok {
+			return x.AfterUpdate(ctx)
+		}
+	case beforeDelete:
+		if x, ok := it.(BeforeDeleter); ok {
+			return x.BeforeDelete(ctx)
+		}
+	case afterDelete:
+		if x, ok := it.(AfterDeleter); ok {
+			return x.AfterDelete(ctx)
+		}
+	case afterLoad:
+		if x, ok := it.(AfterLoader); ok {
+			return x.AfterLoad(ctx)
+		}
+	}
+	return nil
+}
+
+// invokeHooks : invoke the hook on every element of the slice, it will stop on the first error
+func invokeHooks(ctx context.Context, h hook, v reflect.Value) error {
+	for i := 0; i < v.Len(); i++ {
+		if err := invokeHook(ctx, h, v.Index(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/stretchr/testify/require"
+)
+
+type hookEntity struct {
+	Name    string
+	Loaded  bool
+	Invalid bool
+}
+
+func (h *hookEntity) BeforeInsert(ctx context.Context) error {
+	if h.Invalid {
+		return errors.New("invalid entity")
+	}
+	if h.Name == "" {
+		h.Name = "default"
+	}
+	return nil
+}
+
+func (h *hookEntity) AfterLoad(ctx context.Context) error {
+	h.Loaded = true
+	return nil
+}
+
+type valueHookEntity struct{}
+
+func (valueHookEntity) BeforeDelete(ctx context.Context) error {
+	return errors.New("cannot delete")
+}
+
+func TestHook(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Pointer", func(ti *testing.T) {
+		ent := &hookEntity{}
+		require.NoError(ti, invokeHook(ctx, beforeInsert, reflect.ValueOf(ent)))
+		require.Equal(ti, "default", ent.Name)
+		require.NoError(ti, invokeHook(ctx, afterLoad, reflect.ValueOf(ent)))
+		require.True(ti, ent.Loaded)
+		// not implemented hook will be ignored
+		require.NoError(ti, invokeHook(ctx, beforeUpdate, reflect.ValueOf(ent)))
+		require.NoError(ti, invokeHook(ctx, beforeInsert, reflect.ValueOf((*hookEntity)(nil))))
+	})
+
+	t.Run("Slice", func(ti *testing.T) {
+		ents := []hookEntity{{}, {Name: "John"}}
+		require.NoError(ti, invokeHooks(ctx, beforeInsert, reflect.ValueOf(ents)))
+		require.Equal(ti, "default", ents[0].Name)
+		require.Equal(ti, "John", ents[1].Name)
+
+		ents = append(ents, hookEntity{Invalid: true})
+		require.Error(ti, invokeHooks(ctx, beforeInsert, reflect.ValueOf(ents)))
+	})
+
+	t.Run("Value", func(ti *testing.T) {
+		require.Error(ti, invokeHook(ctx, beforeDelete, reflect.ValueOf(valueHookEntity{})))
+		require.Error(ti, invokeHook(ctx, beforeDelete, reflect.ValueOf(&valueHookEntity{})))
+	})
+}
+
+type hookLogKey struct{}
+
+// hookUser : every hook is logged into the slice carried by context
+type hookUser struct {
+	ID   int64 `sqlike:",primary_key"`
+	Name string
+}
+
+func logHook(ctx context.Context, name string) {
+	if l, ok := ctx.Value(hookLogKey{}).(*[]string); ok {
+		*l = append(*l, name)
+	}
+}
+
+func (u *hookUser) BeforeInsert(ctx context.Context) error {
+	if u.Name == "" {
+		return errors.New("name is required")
+	}
+	logHook(ctx, "BeforeInsert")
+	return nil
+}
+func (u *hookUser) AfterInsert(ctx context.Context) error {
+	logHook(ctx, "AfterInsert")
+	return nil
+}
+func (u *hookUser) BeforeUpdate(ctx context.Context) error {
+	logHook(ctx, "BeforeUpdate")
+	return nil
+}
+func (u *hookUser) AfterUpdate(ctx context.Context) error {
+	logHook(ctx, "AfterUpdate")
+	return nil
+}
+func (u *hookUser) BeforeDelete(ctx context.Context) error {
+	logHook(ctx, "BeforeDelete")
+	return nil
+}
+func (u *hookUser) AfterDelete(ctx context.Context) error {
+	logHook(ctx, "AfterDelete")
+	return nil
+}
+func (u *hookUser) AfterLoad(ctx context.Context) error {
+	logHook(ctx, "AfterLoad:"+u.Name)
+	return nil
+}
+
+func TestHookOnTable(t *testing.T) {
+	var hooks []string
+	ctx := context.WithValue(context.Background(), hookLogKey{}, &hooks)
+	db, r := newRecorderDatabase()
+	tb := db.Table("users")
+
+	t.Run("InsertOne", func(ti *testing.T) {
+		hooks, r.Stmts = nil, nil
+		_, err := tb.InsertOne(ctx, &hookUser{ID: 1, Name: "john"})
+		require.NoError(ti, err)
+		require.Equal(ti, []string{"BeforeInsert", "AfterInsert"}, hooks)
+
+		// the insertion is aborted by the hook
+		hooks, r.Stmts = nil, nil
+		_, err = tb.InsertOne(ctx, &hookUser{ID: 2})
+		require.EqualError(ti, err, "name is required")
+		require.Empty(ti, hooks)
+		require.Empty(ti, r.Stmts)
+	})
+
+	t.Run("ModifyOne & DestroyOne", func(ti *testing.T) {
+		hooks, r.Stmts, r.Affected = nil, nil, 1
+		defer func() { r.Affected = 0 }()
+		require.NoError(ti, tb.ModifyOne(ctx, &hookUser{ID: 1, Name: "doe"}))
+		require.NoError(ti, tb.DestroyOne(ctx, &hookUser{ID: 1}))
+		require.Equal(ti, []string{"BeforeUpdate", "AfterUpdate", "BeforeDelete", "AfterDelete"}, hooks)
+
+		// the after hook isn't invoked when nothing is affected
+		hooks, r.Affected = nil, 0
+		require.Equal(ti, ErrNoRecordAffected, tb.ModifyOne(ctx, &hookUser{ID: 1, Name: "doe"}))
+		require.Error(ti, tb.DestroyOne(ctx, &hookUser{ID: 1}))
+		require.Equal(ti, []string{"BeforeUpdate", "BeforeDelete"}, hooks)
+	})
+
+	t.Run("Decode & All", func(ti *testing.T) {
+		r.Columns = []string{"ID", "Name"}
+		r.Rows = [][]driver.Value{{int64(1), "john"}, {int64(2), "doe"}}
+		defer func() { r.Columns, r.Rows = nil, nil }()
+
+		hooks = nil
+		var user hookUser
+		require.NoError(ti, tb.FindOne(ctx, nil).Decode(&user))
+		require.Equal(ti, []string{"AfterLoad:john"}, hooks)
+
+		hooks = nil
+		result, err := tb.Find(ctx, nil)
+		require.NoError(ti, err)
+		var users []hookUser
+		require.NoError(ti, result.All(&users))
+		require.Equal(ti, []string{"AfterLoad:john", "AfterLoad:doe"}, hooks)
+
+		// the context of the query is passed to the hook
+		hooks = nil
+		result, err = db.QueryStmt(ctx, sql.Select().From("db", "users"))
+		require.NoError(ti, err)
+		var ptrs []*hookUser
+		require.NoError(ti, result.All(&ptrs))
+		require.Equal(ti, []string{"AfterLoad:john", "AfterLoad:doe"}, hooks)
+	})
+
+	t.Run("Abort in transaction", func(ti *testing.T) {
+		hooks, r.Stmts = nil, nil
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			if _, err := sess.Table("users").InsertOne(sess, &hookUser{ID: 1, Name: "john"}); err != nil {
+				return err
+			}
+			_, err := sess.Table("users").InsertOne(sess, &hookUser{ID: 2})
+			return err
+		})
+		require.EqualError(ti, err, "name is required")
+		require.Equal(ti, []string{"BeforeInsert", "AfterInsert"}, hooks)
+		require.Equal(ti, []string{
+			"BEGIN",
+			"INSERT INTO `db`.`users` (`ID`,`Name`) VALUES (?,?);",
+			"ROLLBACK",
+		}, r.Stmts)
+	})
+}
@@ -48,7 +48,7 @@ func (idv *IndexView) Create(ctx context.Context, idxs []indexes.Index) error {
 	idv.tb.dialect.CreateIndexes(stmt, idv.tb.dbName, idv.tb.name, idxs, idv.isSupportDesc())
 	_, err := sqldriver.Execute(
 		ctx,
-		idv.tb.driver,
+		idv.tb.executor(ctx),
 		stmt,
 		idv.tb.logger,
 	)
@@ -73,7 +73,7 @@ func (idv *IndexView) CreateIfNotExists(ctx context.Context, idxs []indexes.Inde
 		var count int
 		if err := sqldriver.QueryRowContext(
 			ctx,
-			idv.tb.driver,
+			idv.tb.executor(ctx),
 			stmt,
 			idv.tb.logger,
 		).Scan(&count); err != nil {
@@ -91,7 +91,7 @@ func (idv *IndexView) CreateIfNotExists(ctx context.Context, idxs []indexes.Inde
 	idv.tb.dialect.CreateIndexes(stmt, idv.tb.dbName, idv.tb.name, cols, idv.isSupportDesc())
 	_, err := sqldriver.Execute(
 		ctx,
-		idv.tb.driver,
+		idv.tb.executor(ctx),
 		stmt,
 		idv.tb.logger,
 	)
@@ -105,7 +105,7 @@ func (idv IndexView) DropOne(ctx context.Context, name string) error {
 	idv.tb.dialect.DropIndexes(stmt, idv.tb.dbName, idv.tb.name, []string{name})
 	_, err := sqldriver.Execute(
 		ctx,
-		idv.tb.driver,
+		idv.tb.executor(ctx),
 		stmt,
 		idv.tb.logger,
 	)
@@ -127,7 +127,7 @@ func (idv *IndexView) DropAll(ctx context.Context) error {
 	idv.tb.dialect.DropIndexes(stmt, idv.tb.dbName, idv.tb.name, names)
 	if _, err := sqldriver.Execute(
 		ctx,
-		idv.tb.driver,
+		idv.tb.executor(ctx),
 		stmt,
 		idv.tb.logger,
 	); err != nil {
@@ -141,8 +141,10 @@ func (idv *IndexView) isSupportDesc() bool {
 		return *idv.supportDesc
 	}
 	flag := false
-	if idv.tb.client.driverName == "mysql" &&
-		idv.tb.client.version.GreaterThan(mysql8) {
+	switch idv.tb.client.driverName {
+	case "mysql":
+		flag = idv.tb.client.version.GreaterThan(mysql8)
+	case "postgres", "sqlite", "sqlite3":
 		flag = true
 	}
 	idv.supportDesc = &flag
@@ -37,19 +37,21 @@ func (tb *Table) InsertOne(ctx context.Context, src interface{}, opts ...*option
 
 	arr := reflect.MakeSlice(reflect.SliceOf(t), 0, 1)
 	arr = reflect.Append(arr, v)
-	return insertMany(
+	result, err := insertMany(
 		ctx,
 		tb.dbName,
 		tb.name,
 		tb.pk,
 		tb.client.cache,
 		tb.codec,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
+		tb.client.DriverInfo,
 		tb.logger,
 		arr.Interface(),
 		&opt.InsertOptions,
 	)
+	return result, tb.dialect.MapError(err)
 }
 
 // Insert : insert multiple records. You should always pass in the address of the slice.
@@ -58,22 +60,24 @@ func (tb *Table) Insert(ctx context.Context, src interface{}, opts ...*options.I
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
-	return insertMany(
+	result, err := insertMany(
 		ctx,
 		tb.dbName,
 		tb.name,
 		tb.pk,
 		tb.client.cache,
 		tb.codec,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
+		tb.client.DriverInfo,
 		tb.logger,
 		src,
 		opt,
 	)
+	return result, tb.dialect.MapError(err)
 }
 
-func insertMany(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, cdc codec.Codecer, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, src interface{}, opt *options.InsertOptions) (sql.Result, error) {
+func insertMany(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, cdc codec.Codecer, driver sqldriver.Driver, dialect sqldialect.Dialect, info sqldriver.Info, logger logs.Logger, src interface{}, opt *options.InsertOptions) (sql.Result, error) {
 	v := reflext.ValueOf(src)
 	if !v.IsValid() {
 		return nil, ErrInvalidInput
@@ -94,6 +98,14 @@ func insertMany(ctx context.Context, dbName, tbName, pk string, cache reflext.St
 		return nil, ErrUnaddressableEntity
 	}
 
+	if err := dialect.ValidateInsert(info, opt); err != nil {
+		return nil, err
+	}
+
+	if err := invokeHooks(ctx, beforeInsert, v); err != nil {
+		return nil, err
+	}
+
 	def := cache.CodecByType(t)
 	stmt := sqlstmt.AcquireStmt(dialect)
 	defer sqlstmt.ReleaseStmt(stmt)
@@ -111,10 +123,17 @@ func insertMany(ctx context.Context, dbName, tbName, pk string, cache reflext.St
 	); err != nil {
 		return nil, err
 	}
-	return sqldriver.Execute(
+	result, err := sqldriver.Execute(
 		ctx,
 		driver,
 		stmt,
 		getLogger(logger, opt.Debug),
 	)
+	if err != nil {
+		return nil, err
+	}
+	if err := invokeHooks(ctx, afterInsert, v); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
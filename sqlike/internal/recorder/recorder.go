@@ -0,0 +1,126 @@
+// Package recorder : a fake sql driver for the tests, it records the executed statements and their arguments instead of executing them
+package recorder
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// Recorder : it's a `driver.Connector` which can be opened by `sql.OpenDB` or `sqlike.ConnectDB`
+type Recorder struct {
+	Stmts     []string
+	Args      [][]interface{}
+	CommitErr error
+	ExecErr   error
+	Affected  int64
+	Columns   []string
+	Types     []string
+	Rows      [][]driver.Value
+	// Respond : the rows of the specific query, the default rows are returned if the columns is nil
+	Respond func(query string) ([]string, [][]driver.Value)
+	// Ignore : the query which is not recorded, eg. the query executed on connect
+	Ignore func(query string) bool
+}
+
+// Connect :
+func (r *Recorder) Connect(context.Context) (driver.Conn, error) { return r, nil }
+
+// Driver :
+func (r *Recorder) Driver() driver.Driver { return nil }
+
+// Prepare :
+func (r *Recorder) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("prepare is not supported")
+}
+
+// Close :
+func (r *Recorder) Close() error { return nil }
+
+// Begin :
+func (r *Recorder) Begin() (driver.Tx, error) {
+	r.record("BEGIN", nil)
+	return r, nil
+}
+
+// Commit :
+func (r *Recorder) Commit() error {
+	r.record("COMMIT", nil)
+	return r.CommitErr
+}
+
+// Rollback :
+func (r *Recorder) Rollback() error {
+	r.record("ROLLBACK", nil)
+	return nil
+}
+
+// ExecContext :
+func (r *Recorder) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	r.record(query, args)
+	if r.ExecErr != nil {
+		return nil, r.ExecErr
+	}
+	return driver.RowsAffected(r.Affected), nil
+}
+
+// QueryContext :
+func (r *Recorder) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	r.record(query, args)
+	if r.ExecErr != nil {
+		return nil, r.ExecErr
+	}
+	if r.Respond != nil {
+		if columns, rows := r.Respond(query); columns != nil {
+			return &Rows{columns: columns, rows: rows}, nil
+		}
+	}
+	return &Rows{columns: r.Columns, types: r.Types, rows: r.Rows}, nil
+}
+
+func (r *Recorder) record(query string, args []driver.NamedValue) {
+	if r.Ignore != nil && r.Ignore(query) {
+		return
+	}
+	var values []interface{}
+	if args != nil {
+		values = make([]interface{}, len(args))
+		for i, arg := range args {
+			values[i] = arg.Value
+		}
+	}
+	r.Stmts = append(r.Stmts, query)
+	r.Args = append(r.Args, values)
+}
+
+// Rows : the rows returned by recorder for every query
+type Rows struct {
+	columns []string
+	types   []string
+	rows    [][]driver.Value
+}
+
+// Columns :
+func (rs *Rows) Columns() []string { return rs.columns }
+
+// Close :
+func (rs *Rows) Close() error { return nil }
+
+// ColumnTypeDatabaseTypeName :
+func (rs *Rows) ColumnTypeDatabaseTypeName(i int) string {
+	if i < len(rs.types) {
+		return rs.types[i]
+	}
+	return ""
+}
+
+// Next :
+func (rs *Rows) Next(dest []driver.Value) error {
+	if len(rs.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, rs.rows[0])
+	rs.rows = rs.rows[1:]
+	return nil
+}
@@ -0,0 +1,191 @@
+package migrate
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+var (
+	sqlFileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+	fileRegex    = regexp.MustCompile(`^(\d+)_`)
+	nameRegex    = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// LoadDir : load the sql migration files from the directory, the file name must follow the format `<version>_<name>.up.sql` and `<version>_<name>.down.sql`
+func (m *Migrator) LoadDir(dir string) error {
+	migrations, err := readDir(dir)
+	if err != nil {
+		return err
+	}
+	return m.Register(migrations...)
+}
+
+func readDir(dir string) ([]*Migration, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	dict := make(map[uint64]*Migration)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+		paths := sqlFileRegex.FindStringSubmatch(f.Name())
+		if paths == nil {
+			continue
+		}
+		version, err := strconv.ParseUint(paths[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		b, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		mg, ok := dict[version]
+		if !ok {
+			mg = &Migration{Version: version, Name: paths[2]}
+			dict[version] = mg
+		} else if mg.Name != paths[2] {
+			return nil, fmt.Errorf("migrate: duplicate migration version %d", version)
+		}
+		stmts := sqlutil.SplitStatements(string(b))
+		switch {
+		case paths[3] == "up":
+			mg.Up = Exec(stmts...)
+		// empty rollback file is considered as not rollbackable
+		case len(stmts) > 0:
+			mg.Down = Exec(stmts...)
+		}
+	}
+	migrations := make([]*Migration, 0, len(dict))
+	for _, mg := range dict {
+		migrations = append(migrations, mg)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// Generate : generate the next numbered migration file into the directory from the diff between the entities and the current schema, the key of entities is the table name.
+// The rollback of sql migration will be left empty for you to fill in. It returns the generated file paths.
+func (m *Migrator) Generate(ctx context.Context, dir, name string, entities map[string]interface{}, opts ...*options.GenerateMigrationOptions) ([]string, error) {
+	opt := options.GenerateMigration()
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+
+	tables := make([]string, 0, len(entities))
+	for k := range entities {
+		tables = append(tables, k)
+	}
+	sort.Strings(tables)
+
+	stmts := make([]string, 0)
+	for _, tb := range tables {
+		result, err := m.db.Table(tb).MigrateStatements(ctx, entities[tb], opt.Unsafe)
+		if err != nil {
+			return nil, err
+		}
+		stmts = append(stmts, result...)
+	}
+	if len(stmts) == 0 {
+		return nil, ErrNoChanges
+	}
+
+	version, err := m.nextVersion(dir)
+	if err != nil {
+		return nil, err
+	}
+	return writeMigration(dir, version, name, stmts, opt)
+}
+
+func (m *Migrator) nextVersion(dir string) (uint64, error) {
+	var version uint64
+	for v := range m.migrations {
+		if v > version {
+			version = v
+		}
+	}
+	files, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	for _, f := range files {
+		paths := fileRegex.FindStringSubmatch(f.Name())
+		if paths == nil {
+			continue
+		}
+		v, _ := strconv.ParseUint(paths[1], 10, 64)
+		if v > version {
+			version = v
+		}
+	}
+	return version + 1, nil
+}
+
+func writeMigration(dir string, version uint64, name string, stmts []string, opt *options.GenerateMigrationOptions) ([]string, error) {
+	name = strings.Trim(nameRegex.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		name = "migration"
+	}
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	prefix := filepath.Join(dir, fmt.Sprintf("%04d_%s", version, name))
+
+	files := make(map[string][]byte)
+	switch opt.Format {
+	case options.MigrationGo:
+		b, err := goMigration(opt.Package, version, name, stmts)
+		if err != nil {
+			return nil, err
+		}
+		files[prefix+".go"] = b
+	default:
+		files[prefix+".up.sql"] = []byte(strings.Join(stmts, ";\n\n") + ";\n")
+		files[prefix+".down.sql"] = []byte("-- write the statements to rollback migration " + strconv.FormatUint(version, 10) + "\n")
+	}
+
+	paths := make([]string, 0, len(files))
+	for path, b := range files {
+		if err := os.WriteFile(path, b, 0644); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+func goMigration(pkg string, version uint64, name string, stmts []string) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	buf.WriteString("// Code generated by sqlike migrate.\n\n")
+	buf.WriteString("package " + pkg + "\n\n")
+	buf.WriteString("import " + strconv.Quote("github.com/si3nloong/sqlike/sqlike/migrate") + "\n\n")
+	buf.WriteString("func init() {\n")
+	buf.WriteString("migrate.Register(&migrate.Migration{\n")
+	buf.WriteString("Version: " + strconv.FormatUint(version, 10) + ",\n")
+	buf.WriteString("Name: " + strconv.Quote(name) + ",\n")
+	buf.WriteString("Up: migrate.Exec(\n")
+	for _, stmt := range stmts {
+		buf.WriteString(strconv.Quote(stmt) + ",\n")
+	}
+	buf.WriteString("),\n")
+	buf.WriteString("// Down: migrate.Exec(), write the statements to rollback the migration\n")
+	buf.WriteString("})\n")
+	buf.WriteString("}\n")
+	return format.Source(buf.Bytes())
+}
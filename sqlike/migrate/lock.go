@@ -0,0 +1,65 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// lock : acquire the advisory lock so only one runner can migrate the database at a time, it's a no-op for the driver without advisory lock (sqlite)
+func (m *Migrator) lock(ctx context.Context) (func(), error) {
+	var acquire, release string
+	switch m.client.DriverName() {
+	case "mysql":
+		acquire = "SELECT GET_LOCK(?, ?);"
+		release = "SELECT RELEASE_LOCK(?);"
+	case "postgres":
+		acquire = "SELECT pg_try_advisory_lock(hashtext($1));"
+		release = "SELECT pg_advisory_unlock(hashtext($1));"
+	default:
+		return func() {}, nil
+	}
+
+	// advisory lock is bound to the session, so it must be acquired and released on the same connection
+	conn, err := m.client.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unlock := func() {
+		var ok sql.NullBool
+		conn.QueryRowContext(context.Background(), release, m.opt.LockName).Scan(&ok)
+		conn.Close()
+	}
+
+	var ok sql.NullBool
+	switch m.client.DriverName() {
+	case "mysql":
+		// mysql will wait until the timeout by itself
+		err = conn.QueryRowContext(ctx, acquire, m.opt.LockName, int64(m.opt.LockTimeout.Seconds())).Scan(&ok)
+	default:
+		deadline := time.Now().Add(m.opt.LockTimeout)
+		for {
+			err = conn.QueryRowContext(ctx, acquire, m.opt.LockName).Scan(&ok)
+			if err != nil || ok.Bool || time.Now().After(deadline) {
+				break
+			}
+			select {
+			case <-ctx.Done():
+				err = ctx.Err()
+			case <-time.After(500 * time.Millisecond):
+				continue
+			}
+			break
+		}
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if !ok.Bool {
+		conn.Close()
+		return nil, fmt.Errorf("migrate: unable to acquire lock %q, another migration may be running", m.opt.LockName)
+	}
+	return unlock, nil
+}
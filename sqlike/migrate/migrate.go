@@ -0,0 +1,244 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+// errors : common error of migrate
+var (
+	// ErrNoChanges : the schema is identical with the struct definitions, nothing to generate
+	ErrNoChanges = errors.New("migrate: no changes")
+)
+
+// Func : migration function, it will be executed within a transaction
+type Func func(sess sqlike.SessionContext) error
+
+// Exec : migration function which execute the statements in order
+func Exec(stmts ...string) Func {
+	return func(sess sqlike.SessionContext) error {
+		for _, stmt := range stmts {
+			if _, err := sess.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Migration :
+type Migration struct {
+	Version uint64
+	Name    string
+	Up      Func
+	Down    Func
+}
+
+// History : the record of applied migration
+type History struct {
+	Version   uint64 `sqlike:",primary_key"`
+	Name      string `sqlike:",size=255"`
+	AppliedAt time.Time
+}
+
+var (
+	mutex    sync.Mutex
+	registry = make(map[uint64]*Migration)
+)
+
+// Register : register the migrations globally, it's normally called in `init` of the generated go files. It will panic if the version is duplicated
+func Register(migrations ...*Migration) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	for _, m := range migrations {
+		if err := register(registry, m); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func register(dict map[uint64]*Migration, m *Migration) error {
+	if m == nil || m.Version == 0 {
+		return errors.New("migrate: invalid migration version")
+	}
+	if _, ok := dict[m.Version]; ok {
+		return fmt.Errorf("migrate: duplicate migration version %d", m.Version)
+	}
+	dict[m.Version] = m
+	return nil
+}
+
+// Migrator :
+type Migrator struct {
+	client     *sqlike.Client
+	db         *sqlike.Database
+	opt        *options.MigrateOptions
+	migrations map[uint64]*Migration
+}
+
+// New : create a migrator for the database, all the globally registered migrations will be included
+func New(client *sqlike.Client, dbName string, opts ...*options.MigrateOptions) *Migrator {
+	opt := options.Migrate()
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	if opt.LockName == "" {
+		opt.LockName = "sqlike_migrate:" + dbName
+	}
+	m := &Migrator{
+		client:     client,
+		db:         client.Database(dbName),
+		opt:        opt,
+		migrations: make(map[uint64]*Migration),
+	}
+	mutex.Lock()
+	defer mutex.Unlock()
+	for k, v := range registry {
+		m.migrations[k] = v
+	}
+	return m
+}
+
+// Register : register the migrations to this migrator only
+func (m *Migrator) Register(migrations ...*Migration) error {
+	for _, mg := range migrations {
+		if err := register(m.migrations, mg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Migrations : all the registered migrations ordered by version
+func (m *Migrator) Migrations() []*Migration {
+	migrations := make([]*Migration, 0, len(m.migrations))
+	for _, v := range m.migrations {
+		migrations = append(migrations, v)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations
+}
+
+// Applied : the applied migrations ordered by version, the history is always read from primary
+// because the replicas might not catch up the latest applied migration yet
+func (m *Migrator) Applied(ctx context.Context) ([]History, error) {
+	tb := m.db.Table(m.opt.HistoryTable)
+	if err := tb.Migrate(ctx, History{}); err != nil {
+		return nil, err
+	}
+	result, err := tb.Find(
+		ctx,
+		actions.Find().OrderBy(expr.Asc("Version")),
+		options.Find().SetNoLimit(true).SetPrimary(true),
+	)
+	if err != nil {
+		return nil, err
+	}
+	histories := []History{}
+	if err := result.All(&histories); err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// Pending : the migrations which not yet applied, ordered by version
+func (m *Migrator) Pending(ctx context.Context) ([]*Migration, error) {
+	histories, err := m.Applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return pending(m.Migrations(), histories), nil
+}
+
+// Up : apply all the pending migrations in order, each of them will be applied in its own transaction
+func (m *Migrator) Up(ctx context.Context) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	migrations, err := m.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	for _, mg := range migrations {
+		if mg.Up == nil {
+			return fmt.Errorf("migrate: missing up migration for version %d", mg.Version)
+		}
+		if err := m.db.RunInTransaction(ctx, func(sess sqlike.SessionContext) error {
+			if err := mg.Up(sess); err != nil {
+				return err
+			}
+			_, err := sess.Table(m.opt.HistoryTable).InsertOne(sess, &History{
+				Version:   mg.Version,
+				Name:      mg.Name,
+				AppliedAt: time.Now().UTC(),
+			})
+			return err
+		}, m.txOptions()); err != nil {
+			return fmt.Errorf("migrate: version %d: %w", mg.Version, err)
+		}
+	}
+	return nil
+}
+
+// Down : rollback the latest applied migrations in reverse order
+func (m *Migrator) Down(ctx context.Context, steps uint) error {
+	unlock, err := m.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	histories, err := m.Applied(ctx)
+	if err != nil {
+		return err
+	}
+	for i := len(histories) - 1; i >= 0 && steps > 0; i-- {
+		h := histories[i]
+		mg, ok := m.migrations[h.Version]
+		if !ok || mg.Down == nil {
+			return fmt.Errorf("migrate: missing down migration for version %d", h.Version)
+		}
+		if err := m.db.RunInTransaction(ctx, func(sess sqlike.SessionContext) error {
+			if err := mg.Down(sess); err != nil {
+				return err
+			}
+			return sess.Table(m.opt.HistoryTable).DestroyOne(sess, &h)
+		}, m.txOptions()); err != nil {
+			return fmt.Errorf("migrate: version %d: %w", h.Version, err)
+		}
+		steps--
+	}
+	return nil
+}
+
+func (m *Migrator) txOptions() *options.TransactionOptions {
+	return options.Transaction().SetTimeOut(m.opt.Timeout)
+}
+
+func pending(migrations []*Migration, histories []History) []*Migration {
+	applied := make(map[uint64]bool, len(histories))
+	for _, h := range histories {
+		applied[h.Version] = true
+	}
+	result := make([]*Migration, 0)
+	for _, mg := range migrations {
+		if !applied[mg.Version] {
+			result = append(result, mg)
+		}
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package migrate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/si3nloong/sqlike/sqlike"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestRegister(t *testing.T) {
+	dict := make(map[uint64]*Migration)
+	require.NoError(t, register(dict, &Migration{Version: 1, Name: "init"}))
+	require.Error(t, register(dict, &Migration{Version: 1, Name: "dup"}))
+	require.Error(t, register(dict, &Migration{Name: "zero"}))
+	require.Error(t, register(dict, nil))
+
+	m := &Migrator{migrations: dict}
+	require.NoError(t, m.Register(&Migration{Version: 3}, &Migration{Version: 2}))
+	migrations := m.Migrations()
+	require.Equal(t, 3, len(migrations))
+	require.Equal(t, uint64(1), migrations[0].Version)
+	require.Equal(t, uint64(2), migrations[1].Version)
+	require.Equal(t, uint64(3), migrations[2].Version)
+
+	result := pending(migrations, []History{{Version: 1}, {Version: 3}})
+	require.Equal(t, 1, len(result))
+	require.Equal(t, uint64(2), result[0].Version)
+}
+
+func TestApplied(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	// the replica is a separate database which doesn't have the history table
+	client, err := sqlike.Connect(
+		ctx, "sqlite",
+		options.Connect().
+			ApplyURI(filepath.Join(dir, "primary.db")).
+			SetReplicas(options.Connect().ApplyURI(filepath.Join(dir, "replica.db"))),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	m := New(client, "main", options.Migrate())
+	histories, err := m.Applied(ctx)
+	require.NoError(t, err)
+	require.Empty(t, histories)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	_, err = client.Database("main").Table(m.opt.HistoryTable).InsertOne(ctx, &History{Version: 1, Name: "init", AppliedAt: now})
+	require.NoError(t, err)
+
+	histories, err = m.Applied(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []History{{Version: 1, Name: "init", AppliedAt: now}}, histories)
+}
+
+func TestFile(t *testing.T) {
+	dir := t.TempDir()
+	stmts := []string{
+		"CREATE TABLE `db`.`User` (`ID` BIGINT NOT NULL, PRIMARY KEY (`ID`))",
+		"ALTER TABLE `db`.`User` ADD `Name` VARCHAR(191) NOT NULL COMMENT 'a;b'",
+	}
+
+	t.Run("SQL", func(ti *testing.T) {
+		paths, err := writeMigration(dir, 1, "Create User!", stmts, options.GenerateMigration())
+		require.NoError(ti, err)
+		require.Equal(ti, []string{
+			filepath.Join(dir, "0001_create_user.down.sql"),
+			filepath.Join(dir, "0001_create_user.up.sql"),
+		}, paths)
+
+		b, err := os.ReadFile(paths[1])
+		require.NoError(ti, err)
+		require.Equal(ti, stmts[0]+";\n\n"+stmts[1]+";\n", string(b))
+
+		migrations, err := readDir(dir)
+		require.NoError(ti, err)
+		require.Equal(ti, 1, len(migrations))
+		require.Equal(ti, uint64(1), migrations[0].Version)
+		require.Equal(ti, "create_user", migrations[0].Name)
+		require.NotNil(ti, migrations[0].Up)
+		// rollback file only have comment
+		require.Nil(ti, migrations[0].Down)
+	})
+
+	t.Run("Go", func(ti *testing.T) {
+		m := &Migrator{migrations: map[uint64]*Migration{}}
+		version, err := m.nextVersion(dir)
+		require.NoError(ti, err)
+		require.Equal(ti, uint64(2), version)
+
+		paths, err := writeMigration(dir, version, "add_name", stmts[1:], options.GenerateMigration().SetFormat(options.MigrationGo))
+		require.NoError(ti, err)
+		require.Equal(ti, []string{filepath.Join(dir, "0002_add_name.go")}, paths)
+
+		b, err := os.ReadFile(paths[0])
+		require.NoError(ti, err)
+		require.Equal(ti, `// Code generated by sqlike migrate.
+
+package migrations
+
+import "github.com/si3nloong/sqlike/sqlike/migrate"
+
+func init() {
+	migrate.Register(&migrate.Migration{
+		Version: 2,
+		Name:    "add_name",
+		Up: migrate.Exec(
+			"ALTER TABLE `+"`db`.`User`"+` ADD `+"`Name`"+` VARCHAR(191) NOT NULL COMMENT 'a;b'",
+		),
+		// Down: migrate.Exec(), write the statements to rollback the migration
+	})
+}
+`, string(b))
+
+		// go migration file will be counted in version but not loaded
+		migrations, err := readDir(dir)
+		require.NoError(ti, err)
+		require.Equal(ti, 1, len(migrations))
+
+		m.migrations[10] = &Migration{Version: 10}
+		version, err = m.nextVersion(dir)
+		require.NoError(ti, err)
+		require.Equal(ti, uint64(11), version)
+	})
+}
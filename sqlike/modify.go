@@ -17,18 +17,19 @@ import (
 
 // ModifyOne :
 func (tb *Table) ModifyOne(ctx context.Context, update interface{}, opts ...*options.ModifyOneOptions) error {
-	return modifyOne(
+	err := modifyOne(
 		ctx,
 		tb.dbName,
 		tb.name,
 		tb.pk,
 		tb.client.cache,
 		tb.dialect,
-		tb.driver,
+		tb.executor(ctx),
 		tb.logger,
 		update,
 		opts,
 	)
+	return tb.dialect.MapError(err)
 }
 
 func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.StructMapper, dialect sqldialect.Dialect, driver sqldriver.Driver, logger logs.Logger, update interface{}, opts []*options.ModifyOneOptions) error {
@@ -46,6 +47,10 @@ func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.Str
 		return ErrNilEntity
 	}
 
+	if err := invokeHook(ctx, beforeUpdate, v); err != nil {
+		return err
+	}
+
 	cdc := cache.CodecByType(t)
 	opt := new(options.ModifyOneOptions)
 	if len(opts) > 0 && opts[0] != nil {
@@ -108,5 +113,5 @@ func modifyOne(ctx context.Context, dbName, tbName, pk string, cache reflext.Str
 			return ErrNoRecordAffected
 		}
 	}
-	return nil
+	return invokeHook(ctx, afterUpdate, v)
 }
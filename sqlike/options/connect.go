@@ -3,6 +3,7 @@ package options
 import (
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/si3nloong/sqlike/sql/charset"
 	"github.com/si3nloong/sqlike/sqlike/logs"
@@ -20,8 +21,21 @@ type ConnectOptions struct {
 	Charset  charset.Code
 	Collate  string
 	Logger   logs.Logger
+	// read replicas, reads will be routed to replicas while writes, locking reads and transactions always go to primary
+	Replicas            []*ConnectOptions
+	ReplicaPolicy       ReplicaPolicy
+	HealthCheckInterval time.Duration
 }
 
+// ReplicaPolicy : the policy to pick the replica for reads
+type ReplicaPolicy int
+
+// replica policies :
+const (
+	RoundRobin ReplicaPolicy = iota
+	LeastConnections
+)
+
 // Connect :
 func Connect() *ConnectOptions {
 	return &ConnectOptions{}
@@ -91,3 +105,21 @@ func (opt *ConnectOptions) SetCollate(collate string) *ConnectOptions {
 	opt.Collate = collate
 	return opt
 }
+
+// SetReplicas :
+func (opt *ConnectOptions) SetReplicas(replicas ...*ConnectOptions) *ConnectOptions {
+	opt.Replicas = replicas
+	return opt
+}
+
+// SetReplicaPolicy :
+func (opt *ConnectOptions) SetReplicaPolicy(policy ReplicaPolicy) *ConnectOptions {
+	opt.ReplicaPolicy = policy
+	return opt
+}
+
+// SetHealthCheckInterval : the interval to ping the replicas, unhealthy replica will be evicted until it's reachable again
+func (opt *ConnectOptions) SetHealthCheckInterval(interval time.Duration) *ConnectOptions {
+	opt.HealthCheckInterval = interval
+	return opt
+}
@@ -2,6 +2,7 @@ package options
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -66,4 +67,16 @@ func TestConnect(t *testing.T) {
 		opt.SetCollate("utf8_bin_general")
 		require.Equal(t, "utf8_bin_general", opt.Collate)
 	}
+
+	{
+		replica := Connect().SetHost("192.168.0.11")
+		opt.SetReplicas(replica)
+		require.Equal(t, []*ConnectOptions{replica}, opt.Replicas)
+
+		opt.SetReplicaPolicy(LeastConnections)
+		require.Equal(t, LeastConnections, opt.ReplicaPolicy)
+
+		opt.SetHealthCheckInterval(5 * time.Second)
+		require.Equal(t, 5*time.Second, opt.HealthCheckInterval)
+	}
 }
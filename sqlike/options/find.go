@@ -5,7 +5,11 @@ type FindOptions struct {
 	OmitFields []string
 	NoLimit    bool
 	LockMode   LockMode
-	Debug      bool
+	// the tables (or aliases) to lock, it's useful when there is join
+	LockOf []string
+	Debug  bool
+	// force to read from primary instead of replica, for read-after-write consistency
+	Primary bool
 }
 
 // Find :
@@ -36,3 +40,15 @@ func (opt *FindOptions) SetLockMode(lock LockMode) *FindOptions {
 	opt.LockMode = lock
 	return opt
 }
+
+// SetLockOf : only lock the rows of the tables, eg. `FOR UPDATE OF table`
+func (opt *FindOptions) SetLockOf(tables ...string) *FindOptions {
+	opt.LockOf = tables
+	return opt
+}
+
+// SetPrimary :
+func (opt *FindOptions) SetPrimary(primary bool) *FindOptions {
+	opt.Primary = primary
+	return opt
+}
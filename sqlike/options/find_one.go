@@ -27,3 +27,15 @@ func (opt *FindOneOptions) SetLockMode(lock LockMode) *FindOneOptions {
 	opt.LockMode = lock
 	return opt
 }
+
+// SetLockOf : only lock the rows of the tables, eg. `FOR UPDATE OF table`
+func (opt *FindOneOptions) SetLockOf(tables ...string) *FindOneOptions {
+	opt.LockOf = tables
+	return opt
+}
+
+// SetPrimary :
+func (opt *FindOneOptions) SetPrimary(primary bool) *FindOneOptions {
+	opt.Primary = primary
+	return opt
+}
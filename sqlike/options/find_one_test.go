@@ -56,5 +56,14 @@ func TestFindOne(t *testing.T) {
 			ot := FindOne()
 			require.Equal(it, LockMode(0), ot.LockMode)
 		}
+
+		{
+			opt.SetLockMode(LockForUpdateSkipLocked).SetLockOf("Job", "u")
+			require.Equal(it, LockForUpdateSkipLocked, opt.LockMode)
+			require.Equal(it, []string{"Job", "u"}, opt.LockOf)
+			require.Equal(it, "FOR UPDATE SKIP LOCKED", opt.LockMode.String())
+			require.Equal(it, "FOR UPDATE NOWAIT", LockForUpdateNoWait.String())
+			require.Equal(it, "FOR SHARE SKIP LOCKED", LockForShareSkipLocked.String())
+		}
 	})
 }
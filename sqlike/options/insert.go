@@ -1,6 +1,10 @@
 package options
 
-import "github.com/si3nloong/sqlike/sql/util"
+import (
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/primitive"
+)
 
 type insertMode int
 
@@ -12,9 +16,10 @@ const (
 
 // InsertOptions :
 type InsertOptions struct {
-	Mode  insertMode
-	Omits util.StringSlice
-	Debug bool
+	Mode       insertMode
+	Omits      util.StringSlice
+	OnConflict *OnConflictOptions
+	Debug      bool
 }
 
 // Insert :
@@ -40,7 +45,61 @@ func (opt *InsertOptions) SetOmitFields(fields ...string) *InsertOptions {
 	return opt
 }
 
-// // SetOnConflict :
-// func (opt *InsertOptions) SetOnConflict(src []interface{}) *InsertOptions {
-// 	return opt
-// }
+// SetOnConflict : upsert with the update clause, it will set the mode to `InsertOnDuplicate`
+func (opt *InsertOptions) SetOnConflict(conflict *OnConflictOptions) *InsertOptions {
+	opt.Mode = InsertOnDuplicate
+	opt.OnConflict = conflict
+	return opt
+}
+
+// OnConflictOptions : the update clause of upsert, `ON DUPLICATE KEY UPDATE` in mysql and `ON CONFLICT ... DO UPDATE` in postgres and sqlite.
+// Every column (except primary key and omitted fields) will be updated with the inserted value if there is no columns and values.
+type OnConflictOptions struct {
+	// columns of the unique index or constraint, mysql doesn't support conflict target and it will be ignored
+	Target []string
+	// columns which will be updated with the inserted value
+	Columns []string
+	// update expressions, eg. `expr.ColumnValue("Count", expr.Increment("Count", 1))`
+	Values []primitive.KV
+	// the row will be updated only if the conditions are met, otherwise it's kept as it is
+	Conditions primitive.Group
+	// mysql 8.0.19+ row alias of the inserted row, so it's able to refer the inserted value by `expr.Column(alias, column)`
+	RowAlias string
+}
+
+// OnConflict :
+func OnConflict() *OnConflictOptions {
+	return &OnConflictOptions{}
+}
+
+// SetTarget :
+func (opt *OnConflictOptions) SetTarget(columns ...string) *OnConflictOptions {
+	opt.Target = columns
+	return opt
+}
+
+// SetColumns :
+func (opt *OnConflictOptions) SetColumns(columns ...string) *OnConflictOptions {
+	opt.Columns = columns
+	return opt
+}
+
+// SetValues :
+func (opt *OnConflictOptions) SetValues(values ...primitive.KV) *OnConflictOptions {
+	opt.Values = append(opt.Values, values...)
+	return opt
+}
+
+// SetWhere : in mysql, every assignment is wrapped with `CASE WHEN condition THEN value ELSE column END`.
+// As the assignments are evaluated from left to right, the assignment of the updated column which is referred by the condition
+// will be moved to the last, and it will return error if the condition refers to more than one updated column.
+func (opt *OnConflictOptions) SetWhere(conds ...interface{}) *OnConflictOptions {
+	opt.Conditions = expr.And(conds...)
+	return opt
+}
+
+// SetRowAlias :
+func (opt *OnConflictOptions) SetRowAlias(alias string) *OnConflictOptions {
+	opt.RowAlias = alias
+	return opt
+}
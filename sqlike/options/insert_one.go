@@ -28,7 +28,8 @@ func (opt *InsertOneOptions) SetOmitFields(fields ...string) *InsertOneOptions {
 	return opt
 }
 
-// // SetOnConflict :
-// func (opt *InsertOneOptions) SetOnConflict(src []interface{}) *InsertOneOptions {
-// 	return opt
-// }
+// SetOnConflict : upsert with the update clause, it will set the mode to `InsertOnDuplicate`
+func (opt *InsertOneOptions) SetOnConflict(conflict *OnConflictOptions) *InsertOneOptions {
+	opt.InsertOptions.SetOnConflict(conflict)
+	return opt
+}
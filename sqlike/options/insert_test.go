@@ -3,6 +3,7 @@ package options
 import (
 	"testing"
 
+	"github.com/si3nloong/sqlike/sql/expr"
 	"github.com/stretchr/testify/require"
 )
 
@@ -44,4 +45,21 @@ func TestInsertOptions(t *testing.T) {
 		require.ElementsMatch(it, []string{"test", "__c__"}, opt.Omits)
 	})
 
+	t.Run("SetOnConflict", func(it *testing.T) {
+		ot := Insert()
+		conflict := OnConflict().
+			SetTarget("Email").
+			SetColumns("Name", "Age").
+			SetValues(expr.ColumnValue("Count", expr.Increment("Count", 1))).
+			SetWhere(expr.Equal("Status", "ACTIVE")).
+			SetRowAlias("new")
+		ot.SetOnConflict(conflict)
+		require.Equal(it, InsertOnDuplicate, ot.Mode)
+		require.Equal(it, conflict, ot.OnConflict)
+		require.Equal(it, []string{"Email"}, conflict.Target)
+		require.Equal(it, []string{"Name", "Age"}, conflict.Columns)
+		require.Equal(it, 1, len(conflict.Values))
+		require.Equal(it, 1, len(conflict.Conditions.Values))
+		require.Equal(it, "new", conflict.RowAlias)
+	})
 }
@@ -0,0 +1,110 @@
+package options
+
+import "time"
+
+// Migrate :
+func Migrate() *MigrateOptions {
+	return &MigrateOptions{
+		HistoryTable: "sqlike_migrations",
+		LockTimeout:  10 * time.Second,
+	}
+}
+
+// MigrateOptions :
+type MigrateOptions struct {
+	// table to record the applied migrations
+	HistoryTable string
+
+	// advisory lock name, default to `sqlike_migrate:<database>`
+	LockName string
+
+	// maximum time to wait for the advisory lock
+	LockTimeout time.Duration
+
+	// timeout of each migration transaction
+	Timeout time.Duration
+}
+
+// SetHistoryTable :
+func (opt *MigrateOptions) SetHistoryTable(name string) *MigrateOptions {
+	opt.HistoryTable = name
+	return opt
+}
+
+// SetLockName :
+func (opt *MigrateOptions) SetLockName(name string) *MigrateOptions {
+	opt.LockName = name
+	return opt
+}
+
+// SetLockTimeout :
+func (opt *MigrateOptions) SetLockTimeout(timeout time.Duration) *MigrateOptions {
+	opt.LockTimeout = timeout
+	return opt
+}
+
+// SetTimeOut :
+func (opt *MigrateOptions) SetTimeOut(timeout time.Duration) *MigrateOptions {
+	opt.Timeout = timeout
+	return opt
+}
+
+// MigrationFormat :
+type MigrationFormat int
+
+// migration formats :
+const (
+	MigrationSQL MigrationFormat = iota
+	MigrationGo
+)
+
+// GenerateMigration :
+func GenerateMigration() *GenerateMigrationOptions {
+	return &GenerateMigrationOptions{Package: "migrations"}
+}
+
+// GenerateMigrationOptions :
+type GenerateMigrationOptions struct {
+	Format MigrationFormat
+
+	// package name of the generated go file
+	Package string
+
+	// drop the columns which not exists in struct
+	Unsafe bool
+}
+
+// SetFormat :
+func (opt *GenerateMigrationOptions) SetFormat(format MigrationFormat) *GenerateMigrationOptions {
+	opt.Format = format
+	return opt
+}
+
+// SetPackage :
+func (opt *GenerateMigrationOptions) SetPackage(pkg string) *GenerateMigrationOptions {
+	opt.Package = pkg
+	return opt
+}
+
+// SetUnsafe :
+func (opt *GenerateMigrationOptions) SetUnsafe(unsafe bool) *GenerateMigrationOptions {
+	opt.Unsafe = unsafe
+	return opt
+}
+
+// MigrationPlan :
+func MigrationPlan() *MigrationPlanOptions {
+	return &MigrationPlanOptions{}
+}
+
+// MigrationPlanOptions :
+type MigrationPlanOptions struct {
+	// plan as `UnsafeMigrate`, the columns which not exists in struct will be dropped
+	Unsafe bool
+}
+
+// SetUnsafe :
+func (opt *MigrationPlanOptions) SetUnsafe(unsafe bool) *MigrationPlanOptions {
+	opt.Unsafe = unsafe
+	return opt
+}
@@ -0,0 +1,39 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateOptions(t *testing.T) {
+	opt := Migrate()
+	require.Equal(t, "sqlike_migrations", opt.HistoryTable)
+	require.Equal(t, 10*time.Second, opt.LockTimeout)
+
+	opt.SetHistoryTable("history").SetLockName("lock").SetLockTimeout(time.Minute).SetTimeOut(time.Hour)
+	require.Equal(t, "history", opt.HistoryTable)
+	require.Equal(t, "lock", opt.LockName)
+	require.Equal(t, time.Minute, opt.LockTimeout)
+	require.Equal(t, time.Hour, opt.Timeout)
+}
+
+func TestGenerateMigrationOptions(t *testing.T) {
+	opt := GenerateMigration()
+	require.Equal(t, MigrationSQL, opt.Format)
+	require.Equal(t, "migrations", opt.Package)
+
+	opt.SetFormat(MigrationGo).SetPackage("schema").SetUnsafe(true)
+	require.Equal(t, MigrationGo, opt.Format)
+	require.Equal(t, "schema", opt.Package)
+	require.True(t, opt.Unsafe)
+}
+
+func TestMigrationPlanOptions(t *testing.T) {
+	opt := MigrationPlan()
+	require.False(t, opt.Unsafe)
+
+	opt.SetUnsafe(true)
+	require.True(t, opt.Unsafe)
+}
@@ -8,4 +8,26 @@ const (
 	NoLock LockMode = iota
 	LockForUpdate
 	LockForRead
+	// skip the rows which are locked by other transactions, useful for job queue
+	LockForUpdateSkipLocked
+	// fail immediately instead of waiting if the rows are locked by other transactions
+	LockForUpdateNoWait
+	LockForShareSkipLocked
 )
+
+func (lck LockMode) String() string {
+	switch lck {
+	case LockForUpdate:
+		return "FOR UPDATE"
+	case LockForRead:
+		return "FOR SHARE"
+	case LockForUpdateSkipLocked:
+		return "FOR UPDATE SKIP LOCKED"
+	case LockForUpdateNoWait:
+		return "FOR UPDATE NOWAIT"
+	case LockForShareSkipLocked:
+		return "FOR SHARE SKIP LOCKED"
+	default:
+		return ""
+	}
+}
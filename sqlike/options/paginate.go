@@ -3,6 +3,7 @@ package options
 // PaginateOptions :
 type PaginateOptions struct {
 	FindOptions
+	LookAhead bool
 }
 
 // Paginate :
@@ -15,3 +16,15 @@ func (opt *PaginateOptions) SetDebug(debug bool) *PaginateOptions {
 	opt.Debug = debug
 	return opt
 }
+
+// SetPrimary :
+func (opt *PaginateOptions) SetPrimary(primary bool) *PaginateOptions {
+	opt.Primary = primary
+	return opt
+}
+
+// SetLookAhead : fetch one more record than the limit, the extra record is the cursor of next page
+func (opt *PaginateOptions) SetLookAhead(lookAhead bool) *PaginateOptions {
+	opt.LookAhead = lookAhead
+	return opt
+}
@@ -18,4 +18,14 @@ func TestPaginateOptions(t *testing.T) {
 		opt.SetDebug(false)
 		require.False(t, opt.Debug)
 	}
+
+	{
+		opt.SetPrimary(true)
+		require.True(t, opt.Primary)
+	}
+
+	{
+		opt.SetLookAhead(true)
+		require.True(t, opt.LookAhead)
+	}
 }
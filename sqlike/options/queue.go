@@ -0,0 +1,107 @@
+package options
+
+import "time"
+
+// Queue :
+func Queue() *QueueOptions {
+	return &QueueOptions{
+		Table:             "sqlike_jobs",
+		VisibilityTimeout: 30 * time.Second,
+		MaxAttempts:       5,
+		MinBackoff:        time.Second,
+		MaxBackoff:        time.Hour,
+		PollInterval:      time.Second,
+		BatchSize:         10,
+	}
+}
+
+// QueueOptions :
+type QueueOptions struct {
+	// table to store the jobs, it can be shared by multiple queues
+	Table string
+
+	// the leased job will be visible to other workers again once the timeout is reached
+	VisibilityTimeout time.Duration
+
+	// default maximum attempts of the job before it's dead-lettered
+	MaxAttempts uint
+
+	// the retry delay starts from minimum backoff and doubles on every attempt until maximum backoff
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// the interval of worker to poll when there is no job
+	PollInterval time.Duration
+
+	// number of jobs leased by worker at a time
+	BatchSize uint
+}
+
+// SetTable :
+func (opt *QueueOptions) SetTable(name string) *QueueOptions {
+	opt.Table = name
+	return opt
+}
+
+// SetVisibilityTimeout :
+func (opt *QueueOptions) SetVisibilityTimeout(timeout time.Duration) *QueueOptions {
+	opt.VisibilityTimeout = timeout
+	return opt
+}
+
+// SetMaxAttempts :
+func (opt *QueueOptions) SetMaxAttempts(attempts uint) *QueueOptions {
+	opt.MaxAttempts = attempts
+	return opt
+}
+
+// SetBackoff :
+func (opt *QueueOptions) SetBackoff(min, max time.Duration) *QueueOptions {
+	opt.MinBackoff = min
+	opt.MaxBackoff = max
+	return opt
+}
+
+// SetPollInterval :
+func (opt *QueueOptions) SetPollInterval(interval time.Duration) *QueueOptions {
+	opt.PollInterval = interval
+	return opt
+}
+
+// SetBatchSize :
+func (opt *QueueOptions) SetBatchSize(size uint) *QueueOptions {
+	opt.BatchSize = size
+	return opt
+}
+
+// Enqueue :
+func Enqueue() *EnqueueOptions {
+	return &EnqueueOptions{}
+}
+
+// EnqueueOptions :
+type EnqueueOptions struct {
+	// the job will not be leased before this time, default to now
+	RunAt time.Time
+
+	// override the maximum attempts of queue
+	MaxAttempts uint
+}
+
+// SetRunAt :
+func (opt *EnqueueOptions) SetRunAt(t time.Time) *EnqueueOptions {
+	opt.RunAt = t
+	return opt
+}
+
+// SetDelay : the job will be run after the delay
+func (opt *EnqueueOptions) SetDelay(delay time.Duration) *EnqueueOptions {
+	opt.RunAt = time.Now().Add(delay)
+	return opt
+}
+
+// SetMaxAttempts :
+func (opt *EnqueueOptions) SetMaxAttempts(attempts uint) *EnqueueOptions {
+	opt.MaxAttempts = attempts
+	return opt
+}
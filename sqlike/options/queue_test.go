@@ -0,0 +1,38 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueOptions(t *testing.T) {
+	opt := Queue()
+	require.Equal(t, "sqlike_jobs", opt.Table)
+	require.Equal(t, 30*time.Second, opt.VisibilityTimeout)
+	require.Equal(t, uint(5), opt.MaxAttempts)
+
+	opt.SetTable("jobs").SetVisibilityTimeout(time.Minute).SetMaxAttempts(3).
+		SetBackoff(time.Millisecond, time.Second).SetPollInterval(time.Hour).SetBatchSize(1)
+	require.Equal(t, "jobs", opt.Table)
+	require.Equal(t, time.Minute, opt.VisibilityTimeout)
+	require.Equal(t, uint(3), opt.MaxAttempts)
+	require.Equal(t, time.Millisecond, opt.MinBackoff)
+	require.Equal(t, time.Second, opt.MaxBackoff)
+	require.Equal(t, time.Hour, opt.PollInterval)
+	require.Equal(t, uint(1), opt.BatchSize)
+}
+
+func TestEnqueueOptions(t *testing.T) {
+	opt := Enqueue()
+	require.True(t, opt.RunAt.IsZero())
+
+	now := time.Now()
+	opt.SetRunAt(now).SetMaxAttempts(1)
+	require.Equal(t, now, opt.RunAt)
+	require.Equal(t, uint(1), opt.MaxAttempts)
+
+	opt.SetDelay(time.Hour)
+	require.True(t, opt.RunAt.After(now))
+}
@@ -1,6 +1,7 @@
 package options
 
 import (
+	"context"
 	"database/sql"
 	"time"
 )
@@ -33,6 +34,12 @@ type TransactionOptions struct {
 	Duration       time.Duration
 	IsolationLevel IsolationLevel
 	ReadOnly       bool
+	// maximum attempts of the transaction, including the first attempt
+	RetryAttempts uint
+	// base delay before retry, it grows exponentially with jitter
+	RetryBackoff time.Duration
+	// hook which is invoked before every retry, the attempt starts from 1 on the first retry
+	OnRetry func(ctx context.Context, attempt uint, err error)
 }
 
 // SetTimeOut :
@@ -52,3 +59,16 @@ func (opts *TransactionOptions) SetReadOnly(readOnly bool) *TransactionOptions {
 	opts.ReadOnly = readOnly
 	return opts
 }
+
+// SetRetry : re-run the transaction when it fails on deadlock, serialization failure or lock wait timeout
+func (opts *TransactionOptions) SetRetry(maxAttempts uint, backoff time.Duration) *TransactionOptions {
+	opts.RetryAttempts = maxAttempts
+	opts.RetryBackoff = backoff
+	return opts
+}
+
+// SetOnRetry :
+func (opts *TransactionOptions) SetOnRetry(fn func(ctx context.Context, attempt uint, err error)) *TransactionOptions {
+	opts.OnRetry = fn
+	return opts
+}
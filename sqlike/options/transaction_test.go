@@ -1,6 +1,7 @@
 package options
 
 import (
+	"context"
 	"database/sql"
 	"testing"
 	"time"
@@ -63,4 +64,14 @@ func TestTransactionOptions(t *testing.T) {
 		opt.SetIsolationLevel(sql.LevelLinearizable)
 		require.Equal(it, sql.LevelLinearizable, opt.IsolationLevel)
 	})
+
+	t.Run("SetRetry", func(it *testing.T) {
+		opt.SetRetry(3, time.Millisecond*50)
+		require.Equal(it, uint(3), opt.RetryAttempts)
+		require.Equal(it, time.Millisecond*50, opt.RetryBackoff)
+
+		require.Nil(it, opt.OnRetry)
+		opt.SetOnRetry(func(ctx context.Context, attempt uint, err error) {})
+		require.NotNil(it, opt.OnRetry)
+	})
 }
@@ -16,6 +16,11 @@ var ErrInvalidCursor = errors.New("sqlike: invalid cursor")
 
 // Paginate :
 func (tb *Table) Paginate(ctx context.Context, act actions.PaginateStatement, opts ...*options.PaginateOptions) (*Paginator, error) {
+	return tb.paginate(ctx, tb.pk, act, opts...)
+}
+
+// paginate : the records are lastly sorted by the primary key, so the cursor is able to locate the page
+func (tb *Table) paginate(ctx context.Context, pk string, act actions.PaginateStatement, opts ...*options.PaginateOptions) (*Paginator, error) {
 	x := new(actions.PaginateActions)
 	if act != nil {
 		*x = *(act.(*actions.PaginateActions))
@@ -30,11 +35,11 @@ func (tb *Table) Paginate(ctx context.Context, act actions.PaginateStatement, op
 	// sort by primary key
 	length := len(x.Sorts)
 	fields := make([]interface{}, length+1)
-	sort := expr.Asc(tb.pk)
+	sort := expr.Asc(pk)
 	if length > 0 {
 		x := x.Sorts[length-1].(primitive.Sort)
 		if x.Order == primitive.Descending {
-			sort = expr.Desc(tb.pk)
+			sort = expr.Desc(pk)
 		}
 	}
 	x.Sorts = append(x.Sorts, sort)
@@ -47,9 +52,15 @@ func (tb *Table) Paginate(ctx context.Context, act actions.PaginateStatement, op
 	if x.Count == 0 {
 		x.Count = 100
 	}
+	limit := x.Count
+	if opt.LookAhead {
+		x.Count++
+	}
 	return &Paginator{
 		ctx:    ctx,
 		table:  tb,
+		pk:     pk,
+		limit:  limit,
 		fields: fields,
 		action: x.FindActions,
 		option: &opt.FindOptions,
@@ -60,6 +71,8 @@ func (tb *Table) Paginate(ctx context.Context, act actions.PaginateStatement, op
 type Paginator struct {
 	ctx    context.Context
 	table  *Table
+	pk     string
+	limit  uint
 	fields []interface{}
 	values []interface{}
 	action actions.FindActions
@@ -76,7 +89,7 @@ func (pg *Paginator) NextCursor(ctx context.Context, cursor interface{}) (err er
 		return ErrInvalidCursor
 	}
 	fa := actions.FindOne().Select(pg.fields...).Where(
-		expr.Equal(pg.table.pk, cursor),
+		expr.Equal(pg.pk, cursor),
 	).(*actions.FindOneActions)
 	fa.Limit(1)
 	result := find(
@@ -85,8 +98,9 @@ func (pg *Paginator) NextCursor(ctx context.Context, cursor interface{}) (err er
 		pg.table.name,
 		pg.table.client.cache,
 		pg.table.codec,
-		pg.table.driver,
+		pg.table.reader(ctx, pg.option),
 		pg.table.dialect,
+		pg.table.client.version,
 		pg.table.logger,
 		&fa.FindActions,
 		&options.FindOptions{Debug: pg.option.Debug},
@@ -109,8 +123,9 @@ func (pg *Paginator) All(results interface{}) error {
 		pg.table.name,
 		pg.table.client.cache,
 		pg.table.codec,
-		pg.table.driver,
+		pg.table.reader(pg.ctx, pg.option),
 		pg.table.dialect,
+		pg.table.client.version,
 		pg.table.logger,
 		pg.buildAction(),
 		pg.option,
@@ -0,0 +1,313 @@
+package sqlike
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/si3nloong/sqlike/reflext"
+	sqldialect "github.com/si3nloong/sqlike/sql/dialect"
+	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+// DiffType :
+type DiffType int
+
+// diff types :
+const (
+	// exists in both struct and table
+	Unchanged DiffType = iota
+	// exists in struct only, it will be added
+	Added
+	// exists in table only, it will be dropped by unsafe migration
+	Dropped
+	// exists in table only, it will be kept
+	Untracked
+)
+
+func (dt DiffType) String() string {
+	switch dt {
+	case Added:
+		return "ADDED"
+	case Dropped:
+		return "DROPPED"
+	case Untracked:
+		return "UNTRACKED"
+	default:
+		return "UNCHANGED"
+	}
+}
+
+// ColumnDiff :
+type ColumnDiff struct {
+	Name string
+	Type DiffType
+	// current column in table, it will be nil if the column is going to be added
+	Current *Column
+}
+
+// IndexDiff :
+type IndexDiff struct {
+	Name string
+	Type DiffType
+	// current index in table, it will be nil if the index is going to be added
+	Current *Index
+}
+
+// ForeignKeyDiff :
+type ForeignKeyDiff struct {
+	Name string
+	Type DiffType
+	// current foreign key in table, it will be nil if the foreign key is going to be added
+	Current *ForeignKey
+}
+
+// PlanStatement :
+type PlanStatement struct {
+	SQL string
+	// whether the statement will drop any data, eg. `DROP COLUMN` or `DROP FOREIGN KEY`
+	Destructive bool
+	// the clauses of the statement which drop data
+	Drops []string
+}
+
+// MigrationPlan : the statements which migration would execute, in order
+type MigrationPlan struct {
+	Table       string
+	CreateTable bool
+	Statements  []PlanStatement
+	Columns     []ColumnDiff
+	Indexes     []IndexDiff
+	ForeignKeys []ForeignKeyDiff
+}
+
+// Destructive : whether any column or foreign key is going to be dropped
+func (p *MigrationPlan) Destructive() bool {
+	for _, col := range p.Columns {
+		if col.Type == Dropped {
+			return true
+		}
+	}
+	for _, fk := range p.ForeignKeys {
+		if fk.Type == Dropped {
+			return true
+		}
+	}
+	for _, stmt := range p.Statements {
+		if stmt.Destructive {
+			return true
+		}
+	}
+	return false
+}
+
+// MigrationPlan : dry run of `Migrate` (or `UnsafeMigrate`), it returns the statements and the diff of columns, indexes and foreign keys
+// without altering the table. The statement is destructive if it's having any clause which drops data.
+func (tb *Table) MigrationPlan(ctx context.Context, entity interface{}, opts ...*options.MigrationPlanOptions) (*MigrationPlan, error) {
+	opt := new(options.MigrationPlanOptions)
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+
+	fields, err := entityFields(tb.client.cache, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := new(MigrationPlan)
+	plan.Table = tb.name
+	stmt := sqlstmt.AcquireStmt(tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+
+	var (
+		columns []Column
+		idxs    []Index
+		fks     []ForeignKey
+		drops   []string
+	)
+	plan.CreateTable = !tb.Exists(ctx)
+	if plan.CreateTable {
+		if err := tb.dialect.CreateTable(
+			stmt,
+			tb.dbName,
+			tb.name,
+			tb.pk,
+			tb.client.DriverInfo,
+			fields,
+		); err != nil {
+			return nil, err
+		}
+	} else {
+		columns, err = tb.ListColumns(ctx)
+		if err != nil {
+			return nil, err
+		}
+		idxs, err = tb.ListIndexes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		fks, err = tb.ListForeignKeys(ctx)
+		if err != nil {
+			return nil, err
+		}
+		drops, err = tb.buildAlterTable(ctx, stmt, fields, columns, idxs, fks, opt.Unsafe)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	plan.Columns = diffColumns(fields, columns, opt.Unsafe)
+	plan.Indexes = diffIndexes(tb.dialect, tb.name, fields, idxs, plan.CreateTable)
+	plan.ForeignKeys, err = diffForeignKeys(tb.name, fields, fks, opt.Unsafe)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sql := range sqlutil.SplitStatements(stmt.String()) {
+		ps := PlanStatement{SQL: sql}
+		// every clause belongs to the first statement which is having it
+		for len(drops) > 0 && strings.Contains(sql, drops[0]) {
+			ps.Drops = append(ps.Drops, drops[0])
+			drops = drops[1:]
+		}
+		ps.Destructive = len(ps.Drops) > 0
+		plan.Statements = append(plan.Statements, ps)
+	}
+	return plan, nil
+}
+
+// columnNames : column names of the struct, including the generated columns
+func columnNames(fields []reflext.StructFielder) []string {
+	names := make([]string, 0, len(fields))
+	for _, sf := range fields {
+		names = append(names, sf.Name())
+		if reflext.Deref(sf.Type()).Kind() != reflect.Struct {
+			continue
+		}
+		children := sf.Children()
+		for len(children) > 0 {
+			child := children[0]
+			tag := child.Tag()
+			k1, virtual := tag.LookUp("virtual_column")
+			k2, stored := tag.LookUp("stored_column")
+			switch {
+			case virtual && k1 != "":
+				names = append(names, k1)
+			case stored && k2 != "":
+				names = append(names, k2)
+			case virtual || stored:
+				names = append(names, child.Name())
+			}
+			children = children[1:]
+			children = append(children, child.Children()...)
+		}
+	}
+	return names
+}
+
+func diffColumns(fields []reflext.StructFielder, columns []Column, unsafe bool) []ColumnDiff {
+	existing := make(map[string]int, len(columns))
+	for i, col := range columns {
+		existing[col.Name] = i
+	}
+	diffs := make([]ColumnDiff, 0, len(columns))
+	for _, name := range columnNames(fields) {
+		if i, ok := existing[name]; ok {
+			diffs = append(diffs, ColumnDiff{Name: name, Type: Unchanged, Current: &columns[i]})
+			delete(existing, name)
+			continue
+		}
+		diffs = append(diffs, ColumnDiff{Name: name, Type: Added})
+	}
+	for i, col := range columns {
+		if _, ok := existing[col.Name]; !ok {
+			continue
+		}
+		dt := Untracked
+		if unsafe {
+			dt = Dropped
+		}
+		diffs = append(diffs, ColumnDiff{Name: col.Name, Type: dt, Current: &columns[i]})
+	}
+	return diffs
+}
+
+// diffIndexes : only the unique indexes which are managed by migration will be compared,
+// the column with `auto_increment` tag is having unique index only when it's altered (if the dialect supports)
+func diffIndexes(dialect sqldialect.Dialect, table string, fields []reflext.StructFielder, idxs []Index, create bool) []IndexDiff {
+	existing := make(map[string]int, len(idxs))
+	for i, idx := range idxs {
+		existing[idx.Name] = i
+	}
+	diffs := make([]IndexDiff, 0, len(idxs))
+	for _, sf := range fields {
+		tag := sf.Tag()
+		_, ok1 := tag.LookUp("unique_index")
+		_, ok2 := tag.LookUp("auto_increment")
+		if !ok1 && (!ok2 || create || !dialect.UniqueAutoIncrement()) {
+			continue
+		}
+		name := dialect.UniqueIndexName(table, sf.Name())
+		if i, ok := existing[name]; ok {
+			diffs = append(diffs, IndexDiff{Name: name, Type: Unchanged, Current: &idxs[i]})
+			delete(existing, name)
+			continue
+		}
+		diffs = append(diffs, IndexDiff{Name: name, Type: Added})
+	}
+	// migration never drop indexes
+	for i, idx := range idxs {
+		if _, ok := existing[idx.Name]; !ok {
+			continue
+		}
+		diffs = append(diffs, IndexDiff{Name: idx.Name, Type: Untracked, Current: &idxs[i]})
+	}
+	return diffs
+}
+
+// diffForeignKeys : the foreign key which is managed by migration (named with `foreignkeys.Prefix`) will be dropped
+// once it's no longer declared, the others are only dropped by unsafe migration
+func diffForeignKeys(table string, fields []reflext.StructFielder, fks []ForeignKey, unsafe bool) ([]ForeignKeyDiff, error) {
+	declared, err := foreignkeys.FromFields(fields)
+	if err != nil {
+		return nil, err
+	}
+	names, err := foreignKeyNames(table, fields, fks)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]int, len(names))
+	for i, name := range names {
+		existing[name] = i
+	}
+	_, drops := foreignkeys.Diff(table, declared, names, unsafe)
+	dropped := make(map[string]bool, len(drops))
+	for _, name := range drops {
+		dropped[name] = true
+	}
+
+	diffs := make([]ForeignKeyDiff, 0, len(fks))
+	for _, fk := range declared {
+		name := fk.GetName(table)
+		if i, ok := existing[name]; ok {
+			diffs = append(diffs, ForeignKeyDiff{Name: name, Type: Unchanged, Current: &fks[i]})
+			delete(existing, name)
+			continue
+		}
+		diffs = append(diffs, ForeignKeyDiff{Name: name, Type: Added})
+	}
+	for i, name := range names {
+		if _, ok := existing[name]; !ok {
+			continue
+		}
+		dt := Untracked
+		if dropped[name] {
+			dt = Dropped
+		}
+		diffs = append(diffs, ForeignKeyDiff{Name: fks[i].Name, Type: dt, Current: &fks[i]})
+	}
+	return diffs, nil
+}
@@ -0,0 +1,175 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/si3nloong/sqlike/reflext"
+	sqldialect "github.com/si3nloong/sqlike/sql/dialect"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+	"github.com/stretchr/testify/require"
+)
+
+type planEntity struct {
+	ID      int64  `sqlike:",auto_increment"`
+	Email   string `sqlike:",unique_index"`
+	Name    string
+	Address struct {
+		City string `sqlike:",virtual_column=City"`
+	}
+}
+
+func TestMigrationPlanDiff(t *testing.T) {
+	fields, err := entityFields(reflext.DefaultMapper, planEntity{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ID", "Email", "Name", "Address", "City"}, columnNames(fields))
+
+	_, err = entityFields(reflext.DefaultMapper, nil)
+	require.Equal(t, ErrInvalidInput, err)
+	_, err = entityFields(reflext.DefaultMapper, 100)
+	require.Equal(t, ErrExpectedStruct, err)
+
+	columns := []Column{{Name: "ID"}, {Name: "Name"}, {Name: "Legacy"}}
+
+	t.Run("Columns", func(ti *testing.T) {
+		diffs := diffColumns(fields, columns, false)
+		require.Equal(ti, 6, len(diffs))
+		types := make(map[string]DiffType)
+		for _, d := range diffs {
+			types[d.Name] = d.Type
+		}
+		require.Equal(ti, map[string]DiffType{
+			"ID":      Unchanged,
+			"Email":   Added,
+			"Name":    Unchanged,
+			"Address": Added,
+			"City":    Added,
+			"Legacy":  Untracked,
+		}, types)
+		require.Equal(ti, &columns[2], diffs[5].Current)
+		require.Nil(ti, diffs[1].Current)
+
+		diffs = diffColumns(fields, columns, true)
+		require.Equal(ti, Dropped, diffs[5].Type)
+		require.Equal(ti, "DROPPED", diffs[5].Type.String())
+	})
+
+	t.Run("Indexes", func(ti *testing.T) {
+		dialect := sqldialect.GetDialectByDriver("mysql")
+		email := dialect.UniqueIndexName("users", "Email")
+		id := dialect.UniqueIndexName("users", "ID")
+		idxs := []Index{{Name: "PRIMARY"}, {Name: id}}
+
+		diffs := diffIndexes(dialect, "users", fields, idxs, false)
+		require.Equal(ti, []IndexDiff{
+			{Name: id, Type: Unchanged, Current: &idxs[1]},
+			{Name: email, Type: Added},
+			{Name: "PRIMARY", Type: Untracked, Current: &idxs[0]},
+		}, diffs)
+
+		// the auto increment column doesn't have unique index on create table
+		diffs = diffIndexes(dialect, "users", fields, nil, true)
+		require.Equal(ti, []IndexDiff{{Name: email, Type: Added}}, diffs)
+
+		dialect = sqldialect.GetDialectByDriver("sqlite")
+		diffs = diffIndexes(dialect, "users", fields, nil, false)
+		require.Equal(ti, []IndexDiff{{Name: dialect.UniqueIndexName("users", "Email"), Type: Added}}, diffs)
+	})
+
+	t.Run("Foreign keys", func(ti *testing.T) {
+		type post struct {
+			ID     int64 `sqlike:",primary_key"`
+			UserID int64 `sqlike:",foreign_key=users.ID"`
+			TagID  int64 `sqlike:",foreign_key=tags.ID"`
+		}
+		fields, err := entityFields(reflext.DefaultMapper, post{})
+		require.NoError(ti, err)
+		declared, err := foreignkeys.FromFields(fields)
+		require.NoError(ti, err)
+		user, tag := declared[0].GetName("posts"), declared[1].GetName("posts")
+
+		fks := []ForeignKey{
+			{Name: user, Columns: []string{"UserID"}, RefTable: "users", RefColumns: []string{"ID"}},
+			{Name: "FK_outdated", Columns: []string{"OwnerID"}, RefTable: "users", RefColumns: []string{"ID"}},
+			{Name: "manual", Columns: []string{"OwnerID"}, RefTable: "owners", RefColumns: []string{"ID"}},
+		}
+		diffs, err := diffForeignKeys("posts", fields, fks, false)
+		require.NoError(ti, err)
+		require.Equal(ti, []ForeignKeyDiff{
+			{Name: user, Type: Unchanged, Current: &fks[0]},
+			{Name: tag, Type: Added},
+			// the managed foreign key is dropped once it's no longer declared
+			{Name: "FK_outdated", Type: Dropped, Current: &fks[1]},
+			{Name: "manual", Type: Untracked, Current: &fks[2]},
+		}, diffs)
+
+		diffs, err = diffForeignKeys("posts", fields, fks, true)
+		require.NoError(ti, err)
+		require.Equal(ti, Dropped, diffs[3].Type)
+
+		plan := MigrationPlan{ForeignKeys: diffs}
+		require.True(ti, plan.Destructive())
+	})
+
+	plan := MigrationPlan{Statements: []PlanStatement{{SQL: "ALTER TABLE `a` ADD `b` INT"}}}
+	require.False(t, plan.Destructive())
+	plan.Columns = []ColumnDiff{{Name: "c", Type: Dropped}}
+	require.True(t, plan.Destructive())
+	plan.Columns = nil
+	plan.Statements = append(plan.Statements, PlanStatement{SQL: "ALTER TABLE `a` DROP COLUMN `c`", Destructive: true})
+	require.True(t, plan.Destructive())
+}
+
+func TestMigrationPlan(t *testing.T) {
+	type post struct {
+		ID     int64 `sqlike:",primary_key"`
+		UserID int64 `sqlike:",foreign_key=users.ID"`
+	}
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	tb := db.Table("posts")
+	declared, err := foreignkeys.FromFields(reflext.DefaultMapper.CodecByType(reflect.TypeOf(post{})).Properties())
+	require.NoError(t, err)
+
+	var fks [][]driver.Value
+	r.Respond = func(query string) ([]string, [][]driver.Value) {
+		switch {
+		case strings.Contains(query, "COUNT("), strings.Contains(query, "count("):
+			return []string{"count"}, [][]driver.Value{{int64(1)}}
+		case strings.Contains(query, "COLUMN_NAME, COLUMN_TYPE"):
+			return []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j"}, [][]driver.Value{
+				{int64(1), "ID", "bigint", nil, "NO", "bigint", nil, nil, "", ""},
+				{int64(2), "UserID", "bigint", nil, "NO", "bigint", nil, nil, "", ""},
+			}
+		case strings.Contains(query, "INDEX_NAME"):
+			return []string{"a", "b", "c"}, [][]driver.Value{{"PRIMARY", "BTREE", int64(0)}}
+		case strings.Contains(query, "CONSTRAINT_NAME"):
+			return []string{"a", "b", "c", "d", "e", "f"}, fks
+		}
+		return nil, nil
+	}
+
+	fks = [][]driver.Value{{declared[0].GetName("posts"), "UserID", "users", "ID", "NO ACTION", "NO ACTION"}}
+	plan, err := tb.MigrationPlan(ctx, post{})
+	require.NoError(t, err)
+	require.False(t, plan.CreateTable)
+	require.False(t, plan.Destructive())
+	require.Len(t, plan.Statements, 1)
+	require.Equal(t, []ForeignKeyDiff{{Name: declared[0].GetName("posts"), Type: Unchanged, Current: &ForeignKey{
+		Name: declared[0].GetName("posts"), Columns: []string{"UserID"}, RefTable: "users", RefColumns: []string{"ID"}, OnDelete: "NO ACTION", OnUpdate: "NO ACTION",
+	}}}, plan.ForeignKeys)
+
+	// the foreign key which is no longer declared is dropped
+	fks = append(fks, []driver.Value{"FK_outdated", "OwnerID", "owners", "ID", "NO ACTION", "NO ACTION"})
+	plan, err = tb.MigrationPlan(ctx, post{})
+	require.NoError(t, err)
+	require.True(t, plan.Destructive())
+	require.Equal(t, Dropped, plan.ForeignKeys[1].Type)
+	require.Len(t, plan.Statements, 1)
+	require.True(t, strings.HasPrefix(plan.Statements[0].SQL, "ALTER TABLE `db`.`posts` DROP FOREIGN KEY `FK_outdated`,"))
+	require.True(t, plan.Statements[0].Destructive)
+	require.Equal(t, []string{"DROP FOREIGN KEY `FK_outdated`"}, plan.Statements[0].Drops)
+}
@@ -17,3 +17,39 @@ func (c *Case) Else(result interface{}) *Case {
 	c.ElseClause = result
 	return c
 }
+
+// MatchMode :
+type MatchMode int
+
+// full-text search modifiers
+const (
+	NaturalLanguageMode MatchMode = iota
+	BooleanMode
+	QueryExpansionMode
+)
+
+func (m MatchMode) String() (n string) {
+	switch m {
+	case BooleanMode:
+		n = "IN BOOLEAN MODE"
+	case QueryExpansionMode:
+		n = "WITH QUERY EXPANSION"
+	default:
+		n = "IN NATURAL LANGUAGE MODE"
+	}
+	return
+}
+
+// Match : full-text search, the columns must be covered by a full-text index
+type Match struct {
+	Columns []interface{}
+	Query   interface{}
+	Mode    MatchMode
+}
+
+// Against :
+func (m *Match) Against(query interface{}, mode MatchMode) *Match {
+	m.Query = query
+	m.Mode = mode
+	return m
+}
@@ -17,6 +17,10 @@ const (
 	JSON_REPLACE
 	JSON_REMOVE
 	MEMBER_OF
+	JSON_OVERLAPS
+	JSON_SEARCH
+	JSON_LENGTH
+	JSON_CONTAINS_PATH
 )
 
 var jsonFuncNames = [...]string{
@@ -33,6 +37,10 @@ var jsonFuncNames = [...]string{
 	"JSON_REPLACE",
 	"JSON_REMOVE",
 	"MEMBER OF",
+	"JSON_OVERLAPS",
+	"JSON_SEARCH",
+	"JSON_LENGTH",
+	"JSON_CONTAINS_PATH",
 }
 
 func (f jsonFunction) String() string {
@@ -54,6 +54,11 @@ type CastAs struct {
 	DataType DataType
 }
 
+// Invalid : the expression which is failed to construct, the error will be returned when it's built
+type Invalid struct {
+	Err error
+}
+
 // Func :
 type Func struct {
 	Name string
@@ -117,6 +122,11 @@ type KV struct {
 	Value interface{}
 }
 
+// Inserted : the value of column proposed for insertion, it's only valid on upsert
+type Inserted struct {
+	Field string
+}
+
 type operator int
 
 // operators :
@@ -166,12 +176,51 @@ const (
 	Average
 	Max
 	Min
+	RowNumber
+	Rank
+	DenseRank
+	Lag
+	Lead
+	FirstValue
 )
 
 // Aggregate :
 type Aggregate struct {
 	Field interface{}
 	By    aggregate
+	Args  []interface{}
+}
+
+// Over : turn the aggregate into window function, frame is optional
+func (x Aggregate) Over(partitionBy []interface{}, orderBy []interface{}, frame *Frame) (w Window) {
+	w.Func = x
+	w.PartitionBy = partitionBy
+	w.OrderBy = orderBy
+	w.Frame = frame
+	return
+}
+
+type frameUnit int
+
+// frame units :
+const (
+	Rows frameUnit = iota + 1
+	Range
+)
+
+// Frame : window frame, eg. `ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW`
+type Frame struct {
+	Unit  frameUnit
+	Start interface{}
+	End   interface{}
+}
+
+// Window :
+type Window struct {
+	Func        Aggregate
+	PartitionBy []interface{}
+	OrderBy     []interface{}
+	Frame       *Frame
 }
 
 // As :
@@ -179,3 +228,41 @@ type As struct {
 	Field interface{}
 	Name  string
 }
+
+// JoinType :
+type JoinType int
+
+// join types :
+const (
+	InnerJoin JoinType = iota + 1
+	LeftJoin
+	RightJoin
+	CrossJoin
+)
+
+func (jt JoinType) String() string {
+	switch jt {
+	case LeftJoin:
+		return "LEFT JOIN"
+	case RightJoin:
+		return "RIGHT JOIN"
+	case CrossJoin:
+		return "CROSS JOIN"
+	default:
+		return "INNER JOIN"
+	}
+}
+
+// Join :
+type Join struct {
+	Type  JoinType
+	Table interface{}
+	On    Group
+}
+
+// CTE : common table expression
+type CTE struct {
+	Name    string
+	Columns []string
+	Query   interface{}
+}
@@ -0,0 +1,347 @@
+package queue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/si3nloong/sqlike/jsonb"
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/indexes"
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+// errors : common error of queue
+var (
+	// ErrLeaseLost : the lease of the job is expired and it's leased by another worker
+	ErrLeaseLost = errors.New("queue: lease of the job is lost")
+)
+
+// Status :
+type Status string
+
+// job status :
+const (
+	Pending Status = "PENDING"
+	Running Status = "RUNNING"
+	Dead    Status = "DEAD"
+)
+
+// Job : the record of jobs table
+type Job struct {
+	ID          uuid.UUID `sqlike:",primary_key"`
+	Queue       string    `sqlike:",size=100"`
+	Status      Status    `sqlike:",size=20"`
+	Payload     json.RawMessage
+	Attempts    uint
+	MaxAttempts uint
+	// the job will be leased once it's due, the leased job is due again when the visibility timeout is reached
+	RunAt     time.Time
+	LastError string `sqlike:",longtext"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Decode : decode the payload into dst
+func (j *Job) Decode(dst interface{}) error {
+	return jsonb.Unmarshal(j.Payload, dst)
+}
+
+// Handler : the job will be completed if handler returns nil, otherwise it will be retried with backoff
+type Handler func(ctx context.Context, job *Job) error
+
+// Queue :
+type Queue struct {
+	name string
+	db   *sqlike.Database
+	opt  *options.QueueOptions
+}
+
+// New : create a queue on the database, queues with different name can share the same jobs table
+func New(db *sqlike.Database, name string, opts ...*options.QueueOptions) *Queue {
+	opt := options.Queue()
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	return &Queue{name: name, db: db, opt: opt}
+}
+
+// Migrate : migrate the jobs table and its index
+func (q *Queue) Migrate(ctx context.Context) error {
+	tb := q.db.Table(q.opt.Table)
+	if err := tb.Migrate(ctx, Job{}); err != nil {
+		return err
+	}
+	return tb.Indexes().CreateOneIfNotExists(ctx, indexes.Index{
+		Columns: indexes.Columns("Queue", "Status", "RunAt"),
+	})
+}
+
+// Enqueue : encode the payload using `jsonb` and insert it as pending job
+func (q *Queue) Enqueue(ctx context.Context, payload interface{}, opts ...*options.EnqueueOptions) (*Job, error) {
+	opt := options.Enqueue()
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	job, err := q.newJob(payload, opt, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if _, err := q.db.Table(q.opt.Table).InsertOne(ctx, job); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (q *Queue) newJob(payload interface{}, opt *options.EnqueueOptions, now time.Time) (*Job, error) {
+	b, err := jsonb.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	job := &Job{
+		ID:          uuid.New(),
+		Queue:       q.name,
+		Status:      Pending,
+		Payload:     b,
+		MaxAttempts: q.opt.MaxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if opt.MaxAttempts > 0 {
+		job.MaxAttempts = opt.MaxAttempts
+	}
+	if !opt.RunAt.IsZero() {
+		job.RunAt = opt.RunAt.UTC()
+	}
+	return job, nil
+}
+
+// Lease : lease the due jobs using `SELECT ... FOR UPDATE SKIP LOCKED`, so concurrent workers never lease the same job.
+// The leased job must be completed or failed before the visibility timeout, otherwise it will be leased again.
+// The job which exceeded its maximum attempts without completion (eg. worker crashed) will be dead-lettered instead.
+func (q *Queue) Lease(ctx context.Context, limit uint) ([]*Job, error) {
+	jobs := []*Job{}
+	if err := q.db.RunInTransaction(ctx, func(sess sqlike.SessionContext) error {
+		now := time.Now().UTC()
+		tb := sess.Table(q.opt.Table)
+		result, err := tb.Find(
+			sess,
+			actions.Find().
+				Where(
+					expr.Equal("Queue", q.name),
+					expr.In("Status", []Status{Pending, Running}),
+					expr.LesserOrEqual("RunAt", now),
+				).
+				OrderBy(expr.Asc("RunAt")).
+				Limit(limit),
+			options.Find().SetLockMode(options.LockForUpdateSkipLocked),
+		)
+		if err != nil {
+			return err
+		}
+		due := []*Job{}
+		if err := result.All(&due); err != nil {
+			return err
+		}
+
+		leased, dead := make([]uuid.UUID, 0, len(due)), make([]uuid.UUID, 0)
+		for _, job := range due {
+			if job.Attempts >= job.MaxAttempts {
+				dead = append(dead, job.ID)
+				continue
+			}
+			job.Status = Running
+			job.Attempts++
+			job.RunAt = now.Add(q.opt.VisibilityTimeout)
+			job.UpdatedAt = now
+			leased = append(leased, job.ID)
+			jobs = append(jobs, job)
+		}
+		if len(dead) > 0 {
+			if _, err := tb.Update(
+				sess,
+				actions.Update().
+					Where(expr.In("ID", dead)).
+					Set(
+						expr.ColumnValue("Status", Dead),
+						expr.ColumnValue("LastError", "queue: lease expired on the last attempt"),
+						expr.ColumnValue("UpdatedAt", now),
+					),
+			); err != nil {
+				return err
+			}
+		}
+		if len(leased) > 0 {
+			if _, err := tb.Update(
+				sess,
+				actions.Update().
+					Where(expr.In("ID", leased)).
+					Set(
+						expr.ColumnValue("Status", Running),
+						expr.ColumnValue("Attempts", expr.Increment("Attempts", 1)),
+						expr.ColumnValue("RunAt", now.Add(q.opt.VisibilityTimeout)),
+						expr.ColumnValue("UpdatedAt", now),
+					),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Complete : remove the job from queue, it returns `ErrLeaseLost` if the job is leased by another worker
+func (q *Queue) Complete(ctx context.Context, job *Job) error {
+	affected, err := q.db.Table(q.opt.Table).DeleteOne(
+		ctx,
+		actions.DeleteOne().Where(q.leaseOf(job)...),
+	)
+	if err != nil {
+		return err
+	}
+	if affected < 1 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+// Fail : retry the job with backoff, the job will be dead-lettered once it reached the maximum attempts
+func (q *Queue) Fail(ctx context.Context, job *Job, cause error) error {
+	now := time.Now().UTC()
+	status, runAt := Pending, now.Add(backoff(job.Attempts, q.opt.MinBackoff, q.opt.MaxBackoff))
+	if job.Attempts >= job.MaxAttempts {
+		status, runAt = Dead, now
+	}
+	msg := ""
+	if cause != nil {
+		msg = cause.Error()
+	}
+	affected, err := q.db.Table(q.opt.Table).UpdateOne(
+		ctx,
+		actions.UpdateOne().
+			Where(q.leaseOf(job)...).
+			Set(
+				expr.ColumnValue("Status", status),
+				expr.ColumnValue("RunAt", runAt),
+				expr.ColumnValue("LastError", msg),
+				expr.ColumnValue("UpdatedAt", now),
+			),
+	)
+	if err != nil {
+		return err
+	}
+	if affected < 1 {
+		return ErrLeaseLost
+	}
+	job.Status, job.RunAt, job.LastError, job.UpdatedAt = status, runAt, msg, now
+	return nil
+}
+
+// leaseOf : the attempts is increased on every lease, so it's used to identify the lease
+func (q *Queue) leaseOf(job *Job) []interface{} {
+	return []interface{}{
+		expr.Equal("ID", job.ID),
+		expr.Equal("Status", Running),
+		expr.Equal("Attempts", job.Attempts),
+	}
+}
+
+// DeadLetters : list the dead-lettered jobs of the queue, the latest first
+func (q *Queue) DeadLetters(ctx context.Context, limit uint) ([]*Job, error) {
+	result, err := q.db.Table(q.opt.Table).Find(
+		ctx,
+		actions.Find().
+			Where(
+				expr.Equal("Queue", q.name),
+				expr.Equal("Status", Dead),
+			).
+			OrderBy(expr.Desc("UpdatedAt")).
+			Limit(limit),
+	)
+	if err != nil {
+		return nil, err
+	}
+	jobs := []*Job{}
+	if err := result.All(&jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Requeue : move the dead-lettered job back to queue with its attempts reset
+func (q *Queue) Requeue(ctx context.Context, id uuid.UUID) error {
+	now := time.Now().UTC()
+	affected, err := q.db.Table(q.opt.Table).UpdateOne(
+		ctx,
+		actions.UpdateOne().
+			Where(
+				expr.Equal("ID", id),
+				expr.Equal("Status", Dead),
+			).
+			Set(
+				expr.ColumnValue("Status", Pending),
+				expr.ColumnValue("Attempts", 0),
+				expr.ColumnValue("RunAt", now),
+				expr.ColumnValue("UpdatedAt", now),
+			),
+	)
+	if err != nil {
+		return err
+	}
+	if affected < 1 {
+		return errors.New("queue: dead-lettered job not found")
+	}
+	return nil
+}
+
+// Run : lease and handle the jobs until the context is done, it polls with interval when there is no due job
+func (q *Queue) Run(ctx context.Context, handler Handler) error {
+	for {
+		jobs, err := q.Lease(ctx, q.opt.BatchSize)
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+		for _, job := range jobs {
+			if err := handler(ctx, job); err != nil {
+				if err := q.Fail(ctx, job, err); err != nil && err != ErrLeaseLost {
+					return err
+				}
+				continue
+			}
+			if err := q.Complete(ctx, job); err != nil && err != ErrLeaseLost {
+				return err
+			}
+		}
+		if len(jobs) > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(q.opt.PollInterval):
+		}
+	}
+}
+
+// backoff : exponential backoff of the attempts, capped at max
+func backoff(attempts uint, min, max time.Duration) time.Duration {
+	delay := min
+	for i := uint(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= max {
+			return max
+		}
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
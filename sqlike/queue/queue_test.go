@@ -0,0 +1,225 @@
+package queue
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/si3nloong/sqlike/sqlike"
+	"github.com/si3nloong/sqlike/sqlike/internal/recorder"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type email struct {
+	To      string
+	Subject string
+}
+
+func TestJob(t *testing.T) {
+	q := New(nil, "mailer")
+	now := time.Now().UTC()
+
+	job, err := q.newJob(email{To: "john@example.com", Subject: "Hello"}, options.Enqueue(), now)
+	require.NoError(t, err)
+	require.Equal(t, "mailer", job.Queue)
+	require.Equal(t, Pending, job.Status)
+	require.Equal(t, uint(5), job.MaxAttempts)
+	require.Equal(t, now, job.RunAt)
+
+	var e email
+	require.NoError(t, job.Decode(&e))
+	require.Equal(t, email{To: "john@example.com", Subject: "Hello"}, e)
+
+	runAt := now.Add(time.Hour)
+	job, err = q.newJob("ping", options.Enqueue().SetRunAt(runAt).SetMaxAttempts(1), now)
+	require.NoError(t, err)
+	require.Equal(t, runAt, job.RunAt)
+	require.Equal(t, uint(1), job.MaxAttempts)
+	require.Equal(t, `"ping"`, string(job.Payload))
+}
+
+func TestBackoff(t *testing.T) {
+	min, max := time.Second, 10*time.Second
+	require.Equal(t, time.Second, backoff(0, min, max))
+	require.Equal(t, time.Second, backoff(1, min, max))
+	require.Equal(t, 2*time.Second, backoff(2, min, max))
+	require.Equal(t, 8*time.Second, backoff(4, min, max))
+	require.Equal(t, max, backoff(5, min, max))
+	require.Equal(t, max, backoff(100, min, max))
+}
+
+func newRecorderQueue(t *testing.T, name string) (*Queue, *recorder.Recorder) {
+	r := new(recorder.Recorder)
+	r.Ignore = func(query string) bool {
+		return strings.HasPrefix(query, "USE ") || query == "SELECT VERSION();"
+	}
+	r.Respond = func(query string) ([]string, [][]driver.Value) {
+		switch {
+		case query == "SELECT VERSION();":
+			return []string{"VERSION()"}, [][]driver.Value{{"8.0.30"}}
+		case strings.Contains(query, "INFORMATION_SCHEMA"):
+			// the table and index never exist
+			return []string{"COUNT"}, [][]driver.Value{{int64(0)}}
+		}
+		return nil, nil
+	}
+	client, err := sqlike.ConnectDB(context.Background(), "mysql", r)
+	require.NoError(t, err)
+	return New(client.Database("db"), name), r
+}
+
+func jobRow(id uuid.UUID, status Status, payload string, attempts, maxAttempts int64) []driver.Value {
+	ts := []byte("2020-01-02 03:04:05")
+	return []driver.Value{[]byte(id.String()), []byte("mailer"), []byte(status), []byte(payload), attempts, maxAttempts, ts, []byte(""), ts, ts}
+}
+
+var jobColumns = []string{"ID", "Queue", "Status", "Payload", "Attempts", "MaxAttempts", "RunAt", "LastError", "CreatedAt", "UpdatedAt"}
+
+func TestMigrate(t *testing.T) {
+	q, r := newRecorderQueue(t, "mailer")
+	require.NoError(t, q.Migrate(context.Background()))
+	require.Len(t, r.Stmts, 4)
+	require.True(t, strings.HasPrefix(r.Stmts[1], "CREATE TABLE `db`.`sqlike_jobs` (`ID` VARCHAR(36)"))
+	require.Contains(t, r.Stmts[1], "PRIMARY KEY (`ID`)")
+	require.Equal(t, []interface{}{"db", "sqlike_jobs", "BTREE", true, "Queue", "Status", "RunAt", int64(3)}, r.Args[2])
+	require.Regexp(t, "^ALTER TABLE `db`.`sqlike_jobs` ADD INDEX `\\w+` \\(`Queue`,`Status`,`RunAt`\\);$", r.Stmts[3])
+}
+
+func TestLease(t *testing.T) {
+	ctx := context.Background()
+	q, r := newRecorderQueue(t, "mailer")
+	due, exhausted := uuid.New(), uuid.New()
+	r.Columns = jobColumns
+	r.Rows = [][]driver.Value{
+		jobRow(due, Pending, `{"To":"john@example.com"}`, 0, 5),
+		// the worker crashed on its last attempt
+		jobRow(exhausted, Running, `{"To":"doe@example.com"}`, 5, 5),
+	}
+
+	now := time.Now().UTC()
+	jobs, err := q.Lease(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	require.Equal(t, due, jobs[0].ID)
+	require.Equal(t, Running, jobs[0].Status)
+	require.Equal(t, uint(1), jobs[0].Attempts)
+	require.True(t, jobs[0].RunAt.After(now.Add(29*time.Second)))
+
+	require.Equal(t, []string{
+		"BEGIN",
+		"SELECT * FROM `db`.`sqlike_jobs` WHERE (`Queue` = ? AND `Status` IN (?,?) AND `RunAt` <= ?) ORDER BY `RunAt` LIMIT 10 FOR UPDATE SKIP LOCKED;",
+		"UPDATE `db`.`sqlike_jobs` SET `Status` = ?,`LastError` = ?,`UpdatedAt` = ? WHERE `ID` IN (?);",
+		"UPDATE `db`.`sqlike_jobs` SET `Status` = ?,`Attempts` = `Attempts` + 1,`RunAt` = ?,`UpdatedAt` = ? WHERE `ID` IN (?);",
+		"COMMIT",
+	}, r.Stmts)
+	require.Equal(t, []interface{}{"mailer", "PENDING", "RUNNING"}, r.Args[1][:3])
+	// dead-lettered after the maximum attempts
+	require.Equal(t, "DEAD", r.Args[2][0])
+	require.Equal(t, exhausted.String(), r.Args[2][3])
+	require.Equal(t, "RUNNING", r.Args[3][0])
+	require.Equal(t, due.String(), r.Args[3][3])
+}
+
+func TestCompleteAndFail(t *testing.T) {
+	ctx := context.Background()
+	q, r := newRecorderQueue(t, "mailer")
+	job := &Job{ID: uuid.New(), Status: Running, Attempts: 2, MaxAttempts: 3}
+
+	t.Run("Lease lost", func(ti *testing.T) {
+		r.Stmts, r.Args, r.Affected = nil, nil, 0
+		require.Equal(ti, ErrLeaseLost, q.Complete(ctx, job))
+		require.Equal(ti, ErrLeaseLost, q.Fail(ctx, job, errors.New("timeout")))
+		// the job is untouched when the lease is lost
+		require.Equal(ti, Running, job.Status)
+		require.Empty(ti, job.LastError)
+		require.Equal(ti, []string{
+			"DELETE FROM `db`.`sqlike_jobs` WHERE (`ID` = ? AND `Status` = ? AND `Attempts` = ?) LIMIT 1;",
+			"UPDATE `db`.`sqlike_jobs` SET `Status` = ?,`RunAt` = ?,`LastError` = ?,`UpdatedAt` = ? WHERE (`ID` = ? AND `Status` = ? AND `Attempts` = ?) LIMIT 1;",
+		}, r.Stmts)
+		require.Equal(ti, []interface{}{job.ID.String(), "RUNNING", int64(2)}, r.Args[0])
+	})
+
+	t.Run("Complete", func(ti *testing.T) {
+		r.Stmts, r.Args, r.Affected = nil, nil, 1
+		require.NoError(ti, q.Complete(ctx, job))
+		require.Len(ti, r.Stmts, 1)
+	})
+
+	t.Run("Retry", func(ti *testing.T) {
+		r.Stmts, r.Args, r.Affected = nil, nil, 1
+		now := time.Now().UTC()
+		require.NoError(ti, q.Fail(ctx, job, errors.New("timeout")))
+		require.Equal(ti, Pending, job.Status)
+		require.Equal(ti, "timeout", job.LastError)
+		// retried after the backoff of 2 attempts
+		require.True(ti, !job.RunAt.Before(now.Add(2*time.Second)))
+		require.Equal(ti, []interface{}{"PENDING"}, r.Args[0][:1])
+		require.Equal(ti, []interface{}{job.ID.String(), "RUNNING", int64(2)}, r.Args[0][4:])
+	})
+
+	t.Run("Dead letter", func(ti *testing.T) {
+		r.Stmts, r.Args, r.Affected = nil, nil, 1
+		job.Status, job.Attempts = Running, 3
+		require.NoError(ti, q.Fail(ctx, job, errors.New("timeout")))
+		require.Equal(ti, Dead, job.Status)
+		require.Equal(ti, "DEAD", r.Args[0][0])
+	})
+}
+
+func TestRequeue(t *testing.T) {
+	ctx := context.Background()
+	q, r := newRecorderQueue(t, "mailer")
+	id := uuid.New()
+
+	r.Affected = 1
+	require.NoError(t, q.Requeue(ctx, id))
+	require.Equal(t, []string{
+		"UPDATE `db`.`sqlike_jobs` SET `Status` = ?,`Attempts` = ?,`RunAt` = ?,`UpdatedAt` = ? WHERE (`ID` = ? AND `Status` = ?) LIMIT 1;",
+	}, r.Stmts)
+	require.Equal(t, []interface{}{"PENDING", int64(0)}, r.Args[0][:2])
+	require.Equal(t, []interface{}{id.String(), "DEAD"}, r.Args[0][4:])
+
+	// the job is not dead-lettered
+	r.Affected = 0
+	require.Error(t, q.Requeue(ctx, id))
+}
+
+func TestTypedQueue(t *testing.T) {
+	ctx := context.Background()
+	q, r := newRecorderQueue(t, "mailer")
+	mailer := NewTyped[email](q)
+	require.Equal(t, q, mailer.Queue())
+
+	job, err := mailer.Enqueue(ctx, email{To: "john@example.com", Subject: "Hello"})
+	require.NoError(t, err)
+	require.Len(t, r.Stmts, 1)
+	e, err := Payload[email](job)
+	require.NoError(t, err)
+	require.Equal(t, email{To: "john@example.com", Subject: "Hello"}, e)
+
+	_, err = Payload[int](job)
+	require.Error(t, err)
+
+	// the handler receives the decoded payload, and the job is completed
+	r.Stmts, r.Args, r.Affected = nil, nil, 1
+	r.Columns = jobColumns
+	r.Rows = [][]driver.Value{jobRow(job.ID, Pending, `{"To":"john@example.com","Subject":"Hello"}`, 0, 5)}
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	handled := 0
+	err = mailer.Run(ctx, func(ctx context.Context, job *Job, payload email) error {
+		require.Equal(t, email{To: "john@example.com", Subject: "Hello"}, payload)
+		handled++
+		// nothing to lease afterward
+		r.Rows = nil
+		return nil
+	})
+	require.Equal(t, context.DeadlineExceeded, err)
+	require.Equal(t, 1, handled)
+	require.Contains(t, r.Stmts, "DELETE FROM `db`.`sqlike_jobs` WHERE (`ID` = ? AND `Status` = ? AND `Attempts` = ?) LIMIT 1;")
+}
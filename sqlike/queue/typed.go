@@ -0,0 +1,54 @@
+package queue
+
+import (
+	"context"
+
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+// Payload : decode the payload of the job into T
+//
+//	e, err := queue.Payload[Email](job)
+func Payload[T any](job *Job) (T, error) {
+	var payload T
+	if err := job.Decode(&payload); err != nil {
+		return payload, err
+	}
+	return payload, nil
+}
+
+// TypedHandler : the handler which receives the decoded payload
+type TypedHandler[T any] func(ctx context.Context, job *Job, payload T) error
+
+// TypedQueue : a queue bound with the payload type, so the payload is checked on compile time instead of runtime
+type TypedQueue[T any] struct {
+	q *Queue
+}
+
+// NewTyped :
+//
+//	mailer := queue.NewTyped[Email](queue.New(db, "mailer"))
+func NewTyped[T any](q *Queue) *TypedQueue[T] {
+	return &TypedQueue[T]{q: q}
+}
+
+// Queue : the underlying queue, for the operations which are not payload specific
+func (t *TypedQueue[T]) Queue() *Queue {
+	return t.q
+}
+
+// Enqueue :
+func (t *TypedQueue[T]) Enqueue(ctx context.Context, payload T, opts ...*options.EnqueueOptions) (*Job, error) {
+	return t.q.Enqueue(ctx, payload, opts...)
+}
+
+// Run : the job which payload cannot be decoded is failed like the handler returns error
+func (t *TypedQueue[T]) Run(ctx context.Context, handler TypedHandler[T]) error {
+	return t.q.Run(ctx, func(ctx context.Context, job *Job) error {
+		payload, err := Payload[T](job)
+		if err != nil {
+			return err
+		}
+		return handler(ctx, job, payload)
+	})
+}
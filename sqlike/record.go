@@ -0,0 +1,131 @@
+package sqlike
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/wkb"
+)
+
+var (
+	typeOfRecord   = reflect.TypeOf(Record{})
+	typeOfMap      = reflect.TypeOf(map[string]interface{}{})
+	typeOfGeometry = reflect.TypeOf((*orb.Geometry)(nil)).Elem()
+)
+
+// Record : a dynamic record with ordered columns, the values are converted into go types based on the column types,
+// eg. `DECIMAL` into string (to keep the precision), `JSON` into json.RawMessage, `DATETIME` into time.Time
+// and spatial types into orb.Geometry. The value of `NULL` is nil.
+type Record struct {
+	Columns []string
+	Values  []interface{}
+}
+
+// Get : get the value by column name
+func (r Record) Get(column string) (interface{}, bool) {
+	for i, col := range r.Columns {
+		if col == column {
+			return r.Values[i], true
+		}
+	}
+	return nil, false
+}
+
+// Map : the order of columns will be lost
+func (r Record) Map() map[string]interface{} {
+	m := make(map[string]interface{}, len(r.Columns))
+	for i, col := range r.Columns {
+		m[col] = r.Values[i]
+	}
+	return m
+}
+
+// recordTypes : the go types of the columns, nil if the column type is unknown
+func recordTypes(cts []*sql.ColumnType) []reflect.Type {
+	types := make([]reflect.Type, len(cts))
+	for i, ct := range cts {
+		if ct == nil {
+			continue
+		}
+		name := strings.ToUpper(ct.DatabaseTypeName())
+		unsigned := strings.HasPrefix(name, "UNSIGNED ")
+		name = strings.TrimPrefix(name, "UNSIGNED ")
+		switch name {
+		case "TINYINT", "SMALLINT", "MEDIUMINT", "INT", "INTEGER", "BIGINT", "YEAR", "INT2", "INT4", "INT8":
+			if unsigned {
+				types[i] = reflect.TypeOf(uint64(0))
+			} else {
+				types[i] = reflect.TypeOf(int64(0))
+			}
+		case "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8":
+			types[i] = reflect.TypeOf(float64(0))
+		case "BOOL", "BOOLEAN":
+			types[i] = reflect.TypeOf(false)
+		case "JSON", "JSONB":
+			types[i] = reflect.TypeOf(json.RawMessage{})
+		case "DATE", "DATETIME", "TIMESTAMP", "TIMESTAMPTZ":
+			types[i] = reflect.TypeOf(time.Time{})
+		case "POINT":
+			types[i] = reflect.TypeOf(orb.Point{})
+		case "LINESTRING":
+			types[i] = reflect.TypeOf(orb.LineString{})
+		case "GEOMETRY", "POLYGON", "MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION":
+			types[i] = typeOfGeometry
+		case "BINARY", "VARBINARY", "TINYBLOB", "BLOB", "MEDIUMBLOB", "LONGBLOB", "BYTEA", "BIT":
+			types[i] = reflect.TypeOf([]byte{})
+		case "":
+		default:
+			// `DECIMAL`, `NUMERIC`, `CHAR`, `VARCHAR`, `TEXT`, `ENUM`, `TIME` etc.
+			types[i] = reflect.TypeOf("")
+		}
+	}
+	return types
+}
+
+// record : convert the values of current row into record
+func (r *Result) record(types []reflect.Type, values []interface{}) (Record, error) {
+	// every record owns its columns, so modifying the columns of a record won't affect the others
+	columns := make([]string, len(r.columns))
+	copy(columns, r.columns)
+	rec := Record{Columns: columns, Values: make([]interface{}, len(values))}
+	for i, it := range values {
+		if it == nil || i >= len(types) || types[i] == nil {
+			if b, ok := it.([]byte); ok {
+				it = string(b)
+			}
+			rec.Values[i] = it
+			continue
+		}
+
+		t := types[i]
+		if t == typeOfGeometry {
+			// mysql stores spatial value in SRID + WKB format
+			b, ok := it.([]byte)
+			if !ok || len(b) < 4 {
+				rec.Values[i] = it
+				continue
+			}
+			g, err := wkb.Unmarshal(b[4:])
+			if err != nil {
+				return rec, err
+			}
+			rec.Values[i] = g
+			continue
+		}
+
+		decoder, err := r.codec.LookupDecoder(t)
+		if err != nil {
+			return rec, err
+		}
+		v := reflect.New(t).Elem()
+		if err := decoder(it, v); err != nil {
+			return rec, err
+		}
+		rec.Values[i] = v.Interface()
+	}
+	return rec, nil
+}
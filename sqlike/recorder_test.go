@@ -0,0 +1,19 @@
+package sqlike
+
+import (
+	"database/sql"
+
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql/codec"
+	"github.com/si3nloong/sqlike/sql/dialect/mysql"
+	"github.com/si3nloong/sqlike/sqlike/internal/recorder"
+)
+
+func newRecorderDatabase() (*Database, *recorder.Recorder) {
+	r := new(recorder.Recorder)
+	db := sql.OpenDB(r)
+	client := &Client{DB: db, DriverInfo: new(DriverInfo), dialect: mysql.New()}
+	client.cache = reflext.DefaultMapper
+	client.codec = codec.DefaultRegistry
+	return &Database{name: "db", client: client, dialect: client.dialect, codec: client.codec, driver: db}, r
+}
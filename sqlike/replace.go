@@ -30,17 +30,19 @@ func (tb *Table) ReplaceOne(ctx context.Context, src interface{}, opts ...*optio
 
 	arr := reflect.MakeSlice(reflect.SliceOf(t), 0, 1)
 	arr = reflect.Append(arr, v)
-	return insertMany(
+	result, err := insertMany(
 		ctx,
 		tb.dbName,
 		tb.name,
 		tb.pk,
 		tb.client.cache,
 		tb.codec,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
+		tb.client.DriverInfo,
 		tb.logger,
 		arr.Interface(),
 		&opt.InsertOptions,
 	)
+	return result, tb.dialect.MapError(err)
 }
@@ -0,0 +1,185 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	sqldriver "github.com/si3nloong/sqlike/sql/driver"
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+const defaultHealthCheckInterval = 10 * time.Second
+
+type primaryCtxKey struct{}
+
+// WithPrimary : force every read within the context to go to primary, for read-after-write consistency
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryCtxKey{}, true)
+}
+
+func isPrimary(ctx context.Context) bool {
+	v, _ := ctx.Value(primaryCtxKey{}).(bool)
+	return v
+}
+
+// replica : the read on replica will mark it unhealthy when it's failed with connection error,
+// so the following reads are routed to the others until the health check is able to reach it again
+type replica struct {
+	db        *sql.DB
+	unhealthy atomic.Bool
+}
+
+// ExecContext :
+func (r *replica) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	r.observe(err)
+	return result, err
+}
+
+// QueryContext :
+func (r *replica) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	r.observe(err)
+	return rows, err
+}
+
+// QueryRowContext :
+func (r *replica) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	row := r.db.QueryRowContext(ctx, query, args...)
+	r.observe(row.Err())
+	return row
+}
+
+func (r *replica) observe(err error) {
+	if isConnError(err) {
+		r.unhealthy.Store(true)
+	}
+}
+
+// isConnError : the error of the query itself (eg. syntax error) and the cancellation of context are not connection error
+func isConnError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+// replicaSet : the read replicas of the primary, the unhealthy replica will be skipped until it's reachable again
+type replicaSet struct {
+	policy   options.ReplicaPolicy
+	replicas []*replica
+	next     atomic.Uint64
+	done     chan struct{}
+	once     sync.Once
+}
+
+func newReplicaSet(dbs []*sql.DB, policy options.ReplicaPolicy, interval time.Duration) *replicaSet {
+	rs := &replicaSet{policy: policy, done: make(chan struct{})}
+	for _, db := range dbs {
+		rs.replicas = append(rs.replicas, &replica{db: db})
+	}
+	if interval <= 0 {
+		interval = defaultHealthCheckInterval
+	}
+	// the unreachable replica is skipped from the first read
+	rs.ping(interval)
+	go rs.healthCheck(interval)
+	return rs
+}
+
+// pick : returns nil if there is no healthy replica
+func (rs *replicaSet) pick() *replica {
+	var picked *replica
+	switch rs.policy {
+	case options.LeastConnections:
+		inUse := -1
+		for _, r := range rs.replicas {
+			if r.unhealthy.Load() {
+				continue
+			}
+			if n := r.db.Stats().InUse; inUse < 0 || n < inUse {
+				picked, inUse = r, n
+			}
+		}
+	default:
+		n := uint64(len(rs.replicas))
+		start := rs.next.Add(1)
+		for i := uint64(0); i < n; i++ {
+			if r := rs.replicas[(start+i)%n]; !r.unhealthy.Load() {
+				picked = r
+				break
+			}
+		}
+	}
+	return picked
+}
+
+func (rs *replicaSet) healthCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-rs.done:
+			return
+		case <-ticker.C:
+			rs.ping(interval)
+		}
+	}
+}
+
+func (rs *replicaSet) ping(timeout time.Duration) {
+	for _, r := range rs.replicas {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		r.unhealthy.Store(r.db.PingContext(ctx) != nil)
+		cancel()
+	}
+}
+
+func (rs *replicaSet) close() (err error) {
+	rs.once.Do(func() {
+		close(rs.done)
+		for _, r := range rs.replicas {
+			if e := r.db.Close(); e != nil && err == nil {
+				err = e
+			}
+		}
+	})
+	return
+}
+
+// reader : route the read to replica, only the read on the primary connection pool will be routed,
+// so the read within transaction stays on the transaction
+func (c *Client) reader(ctx context.Context, driver sqldriver.Driver, primary bool) sqldriver.Driver {
+	if c.replicas == nil || primary || isPrimary(ctx) {
+		return driver
+	}
+	if db, ok := driver.(*sql.DB); !ok || db != c.DB {
+		return driver
+	}
+	if r := c.replicas.pick(); r != nil {
+		return r
+	}
+	// fallback to primary when every replica is unhealthy
+	return driver
+}
+
+// Close : close the primary and replicas connection
+func (c *Client) Close() error {
+	if c.replicas != nil {
+		if err := c.replicas.close(); err != nil {
+			c.DB.Close()
+			return err
+		}
+	}
+	return c.DB.Close()
+}
@@ -0,0 +1,98 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/si3nloong/sqlike/sqlike/internal/recorder"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+type unreachableConnector struct{}
+
+func (unreachableConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("unreachable")
+}
+
+func (unreachableConnector) Driver() driver.Driver { return nil }
+
+func TestReplica(t *testing.T) {
+	ctx := context.Background()
+	primary := sql.OpenDB(unreachableConnector{})
+	rec1, rec2 := new(recorder.Recorder), new(recorder.Recorder)
+	r1, r2 := sql.OpenDB(rec1), sql.OpenDB(rec2)
+
+	client := &Client{DB: primary}
+	rs := newReplicaSet([]*sql.DB{r1, r2}, options.RoundRobin, time.Hour)
+	client.replicas = rs
+	defer client.Close()
+	replica1, replica2 := rs.replicas[0], rs.replicas[1]
+
+	t.Run("RoundRobin", func(ti *testing.T) {
+		require.Equal(ti, replica2, rs.pick())
+		require.Equal(ti, replica1, rs.pick())
+		require.Equal(ti, replica2, client.reader(ctx, primary, false))
+		require.Equal(ti, replica1, client.reader(ctx, primary, false))
+	})
+
+	t.Run("Primary", func(ti *testing.T) {
+		require.Equal(ti, primary, client.reader(ctx, primary, true))
+		require.Equal(ti, primary, client.reader(WithPrimary(ctx), primary, false))
+		// transaction never be routed
+		tx := new(sql.Tx)
+		require.Equal(ti, tx, client.reader(ctx, tx, false))
+	})
+
+	t.Run("LeastConnections", func(ti *testing.T) {
+		rs.policy = options.LeastConnections
+		require.Equal(ti, replica1, rs.pick())
+		rs.policy = options.RoundRobin
+	})
+
+	t.Run("Eviction", func(ti *testing.T) {
+		// the error of query itself doesn't evict the replica
+		rec1.ExecErr = errors.New("syntax error")
+		_, err := replica1.QueryContext(ctx, "SELECT 1;")
+		require.Error(ti, err)
+		require.False(ti, replica1.unhealthy.Load())
+
+		// the replica is evicted on connection error
+		rec1.ExecErr = &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+		require.Error(ti, replica1.QueryRowContext(ctx, "SELECT 1;").Scan(new(int)))
+		require.True(ti, replica1.unhealthy.Load())
+		require.Equal(ti, replica2, rs.pick())
+		require.Equal(ti, replica2, rs.pick())
+
+		// the replica is back once it's reachable
+		rec1.ExecErr = nil
+		rs.ping(time.Second)
+		require.False(ti, replica1.unhealthy.Load())
+
+		rec2.ExecErr = driver.ErrBadConn
+		_, err = replica2.ExecContext(ctx, "SELECT 1;")
+		require.Error(ti, err)
+		require.True(ti, replica2.unhealthy.Load())
+		require.Equal(ti, replica1, client.reader(ctx, primary, false))
+	})
+
+	t.Run("Unreachable on start", func(ti *testing.T) {
+		down := sql.OpenDB(unreachableConnector{})
+		rs := newReplicaSet([]*sql.DB{down}, options.RoundRobin, time.Hour)
+		defer rs.close()
+		require.True(ti, rs.replicas[0].unhealthy.Load())
+		require.Nil(ti, rs.pick())
+
+		// every replica is unreachable, so it will fallback to primary
+		client := &Client{DB: primary, replicas: rs}
+		require.Equal(ti, primary, client.reader(ctx, primary, false))
+	})
+
+	require.NoError(t, client.Close())
+	require.NoError(t, client.Close())
+}
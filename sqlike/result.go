@@ -1,8 +1,10 @@
 package sqlike
 
 import (
+	"context"
 	"database/sql"
 	"io"
+	"iter"
 	"reflect"
 
 	"errors"
@@ -28,6 +30,7 @@ var EOF = io.EOF
 
 // Result :
 type Result struct {
+	ctx         context.Context
 	close       bool
 	rows        *sql.Rows
 	codec       codec.Codecer
@@ -102,7 +105,7 @@ func (r *Result) Scan(dests ...interface{}) error {
 	return r.Close()
 }
 
-// Decode will decode the current document into val, this will only accepting pointer of struct as an input.
+// Decode will decode the current document into val, this will only accepting pointer of struct, `map[string]interface{}` or `Record` as an input.
 func (r *Result) Decode(dst interface{}) error {
 	if r.close {
 		defer r.Close()
@@ -122,8 +125,27 @@ func (r *Result) Decode(dst interface{}) error {
 	}
 
 	t = reflext.Deref(t)
+	if t == typeOfRecord || t == typeOfMap {
+		values, err := r.values()
+		if err != nil {
+			return err
+		}
+		rec, err := r.record(recordTypes(r.columnTypes), values)
+		if err != nil {
+			return err
+		}
+		if t == typeOfRecord {
+			reflext.IndirectInit(v).Set(reflect.ValueOf(rec))
+		} else {
+			reflext.IndirectInit(v).Set(reflect.ValueOf(rec.Map()))
+		}
+		if r.close {
+			return r.Close()
+		}
+		return nil
+	}
 	if !reflext.IsKind(t, reflect.Struct) {
-		return errors.New("sqlike: it must be a struct to decode")
+		return errors.New("sqlike: it must be a struct, map[string]interface{} or Record to decode")
 	}
 
 	idxs := r.cache.TraversalsByName(t, r.columns)
@@ -146,6 +168,9 @@ func (r *Result) Decode(dst interface{}) error {
 		}
 	}
 	reflext.IndirectInit(v).Set(reflext.Indirect(vv))
+	if err := invokeHook(r.context(), afterLoad, reflext.Indirect(v)); err != nil {
+		return err
+	}
 	if r.close {
 		return r.Close()
 	}
@@ -221,6 +246,26 @@ func (r *Result) All(results interface{}) error {
 	length := len(r.columns)
 	slice := reflect.MakeSlice(t, 0, 0)
 	t = t.Elem()
+	if t == typeOfRecord || t == typeOfMap {
+		types := recordTypes(r.columnTypes)
+		for r.rows.Next() {
+			values, err := r.values()
+			if err != nil {
+				return err
+			}
+			rec, err := r.record(types, values)
+			if err != nil {
+				return err
+			}
+			if t == typeOfRecord {
+				slice = reflect.Append(slice, reflect.ValueOf(rec))
+			} else {
+				slice = reflect.Append(slice, reflect.ValueOf(rec.Map()))
+			}
+		}
+		v.Set(slice)
+		return r.rows.Close()
+	}
 	idxs := r.cache.TraversalsByName(t, r.columns)
 	decoders := make([]codec.ValueDecoder, length)
 	for i := 0; r.rows.Next(); i++ {
@@ -247,10 +292,130 @@ func (r *Result) All(results interface{}) error {
 		}
 		slice = reflect.Append(slice, vv)
 	}
+	if err := invokeHooks(r.context(), afterLoad, slice); err != nil {
+		return err
+	}
 	v.Set(slice)
 	return r.rows.Close()
 }
 
+// Rows : decode the records one by one instead of buffering them into a slice, the result will be closed
+// once the iteration is done or stopped.
+//
+//	for user, err := range sqlike.Rows[User](result) {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func Rows[T any](r *Result) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		if r == nil {
+			yield(zero, ErrInvalidInput)
+			return
+		}
+		defer r.Close()
+		if r.err != nil {
+			yield(zero, r.err)
+			return
+		}
+
+		t := reflect.TypeFor[T]()
+		if !reflext.IsKind(reflext.Deref(t), reflect.Struct) {
+			yield(zero, errors.New("sqlike: it must be a struct to decode"))
+			return
+		}
+
+		idxs := r.cache.TraversalsByName(t, r.columns)
+		decoders := make([]codec.ValueDecoder, len(r.columns))
+		for i := 0; r.rows.Next(); i++ {
+			values, err := r.values()
+			if err != nil {
+				yield(zero, err)
+				return
+			}
+			vv := reflext.Zero(t)
+			for j, idx := range idxs {
+				if idx == nil {
+					continue
+				}
+				fv := r.cache.FieldByIndexes(vv, idx)
+				if i < 1 {
+					decoder, err := r.codec.LookupDecoder(fv.Type())
+					if err != nil {
+						yield(zero, err)
+						return
+					}
+					decoders[j] = decoder
+				}
+				if err := decoders[j](values[j], fv); err != nil {
+					yield(zero, err)
+					return
+				}
+			}
+			if err := invokeHook(r.context(), afterLoad, vv); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(vv.Interface().(T), nil) {
+				return
+			}
+		}
+		if err := r.rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// Records : iterate the rows as `Record` without knowing the struct of the result, use `Record.Map` to get the row
+// as `map[string]interface{}`. The result will be closed once the iteration is done or stopped.
+//
+//	for rec, err := range result.Records() {
+//		if err != nil {
+//			return err
+//		}
+//	}
+func (r *Result) Records() iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		if r == nil {
+			yield(Record{}, ErrInvalidInput)
+			return
+		}
+		defer r.Close()
+		if r.err != nil {
+			yield(Record{}, r.err)
+			return
+		}
+
+		types := recordTypes(r.columnTypes)
+		for r.rows.Next() {
+			values, err := r.values()
+			if err != nil {
+				yield(Record{}, err)
+				return
+			}
+			rec, err := r.record(types, values)
+			if err != nil {
+				yield(Record{}, err)
+				return
+			}
+			if !yield(rec, nil) {
+				return
+			}
+		}
+		if err := r.rows.Err(); err != nil {
+			yield(Record{}, err)
+		}
+	}
+}
+
+func (r *Result) context() context.Context {
+	if r.ctx == nil {
+		return context.Background()
+	}
+	return r.ctx
+}
+
 // Error :
 func (r *Result) Error() error {
 	if r.rows != nil {
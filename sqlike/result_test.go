@@ -0,0 +1,264 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/paulmach/orb"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRows(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	tb := db.Table("users")
+	r.Columns = []string{"ID", "Name"}
+
+	t.Run("Iterate all", func(ti *testing.T) {
+		r.Rows = [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}
+		result, err := tb.Find(ctx, nil)
+		require.NoError(ti, err)
+
+		var users []*typedUser
+		for user, err := range Rows[*typedUser](result) {
+			require.NoError(ti, err)
+			users = append(users, user)
+		}
+		require.Equal(ti, []*typedUser{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, users)
+	})
+
+	t.Run("Break", func(ti *testing.T) {
+		r.Rows = [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}
+		result, err := tb.Find(ctx, nil)
+		require.NoError(ti, err)
+
+		for user := range Rows[typedUser](result) {
+			require.Equal(ti, typedUser{ID: 1, Name: "a"}, user)
+			break
+		}
+		// the rows is closed on early break
+		_, err = result.rows.Columns()
+		require.Error(ti, err)
+	})
+
+	t.Run("Error", func(ti *testing.T) {
+		r.ExecErr = errors.New("query failed")
+		result, err := tb.Find(ctx, nil)
+		require.Error(ti, err)
+		for _, err := range Rows[typedUser](result) {
+			require.Equal(ti, ErrInvalidInput, err)
+		}
+
+		r.ExecErr = nil
+		r.Rows = [][]driver.Value{{int64(1), "a"}}
+		result, err = tb.Find(ctx, nil)
+		require.NoError(ti, err)
+		for _, err := range Rows[string](result) {
+			require.Error(ti, err)
+		}
+	})
+}
+
+func TestRecords(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	tb := db.Table("users")
+	r.Columns = []string{"ID", "Name"}
+
+	t.Run("Iterate all", func(ti *testing.T) {
+		r.Rows = [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}
+		result, err := tb.Find(ctx, nil)
+		require.NoError(ti, err)
+
+		var rows []map[string]interface{}
+		for rec, err := range result.Records() {
+			require.NoError(ti, err)
+			rows = append(rows, rec.Map())
+		}
+		require.Equal(ti, []map[string]interface{}{
+			{"ID": int64(1), "Name": "a"},
+			{"ID": int64(2), "Name": "b"},
+		}, rows)
+	})
+
+	t.Run("Break", func(ti *testing.T) {
+		r.Rows = [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}}
+		result, err := tb.Find(ctx, nil)
+		require.NoError(ti, err)
+
+		for rec := range result.Records() {
+			require.Equal(ti, []string{"ID", "Name"}, rec.Columns)
+			break
+		}
+		// the rows is closed on early break
+		_, err = result.rows.Columns()
+		require.Error(ti, err)
+	})
+
+	t.Run("Error", func(ti *testing.T) {
+		r.ExecErr = errors.New("query failed")
+		result, err := tb.Find(ctx, nil)
+		require.Error(ti, err)
+		for _, err := range result.Records() {
+			require.Equal(ti, ErrInvalidInput, err)
+		}
+		r.ExecErr = nil
+	})
+}
+
+func TestDecodeRecord(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	tb := db.Table("users")
+	r.Columns = []string{"ID", "Balance", "Profile", "CreatedAt", "Location", "Remark", "Extra"}
+	r.Types = []string{"UNSIGNED BIGINT", "DECIMAL", "JSON", "DATETIME", "POINT", "VARCHAR", ""}
+	point := []byte{0, 0, 0, 0, 1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 240, 63, 0, 0, 0, 0, 0, 0, 0, 64}
+	r.Rows = [][]driver.Value{
+		{[]byte("1"), []byte("10.50"), []byte(`{"a": 1}`), []byte("2020-01-02 03:04:05"), point, nil, []byte("x")},
+		{[]byte("2"), []byte("0.00"), []byte(`[]`), []byte("2021-01-02 03:04:05"), point, []byte("ok"), nil},
+	}
+	record := Record{
+		Columns: r.Columns,
+		Values: []interface{}{
+			uint64(1),
+			"10.50",
+			json.RawMessage(`{"a":1}`),
+			time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			orb.Point{1, 2},
+			nil,
+			"x",
+		},
+	}
+
+	t.Run("Decode", func(ti *testing.T) {
+		var rec Record
+		err := tb.FindOne(ctx, nil).Decode(&rec)
+		require.NoError(ti, err)
+		require.Equal(ti, record, rec)
+		v, ok := rec.Get("Balance")
+		require.True(ti, ok)
+		require.Equal(ti, "10.50", v)
+		_, ok = rec.Get("Unknown")
+		require.False(ti, ok)
+
+		m := map[string]interface{}{}
+		err = tb.FindOne(ctx, nil).Decode(&m)
+		require.NoError(ti, err)
+		require.Equal(ti, record.Map(), m)
+	})
+
+	t.Run("All", func(ti *testing.T) {
+		result, err := tb.Find(ctx, nil)
+		require.NoError(ti, err)
+		var records []Record
+		require.NoError(ti, result.All(&records))
+		require.Len(ti, records, 2)
+		require.Equal(ti, record, records[0])
+		require.Equal(ti, "ok", records[1].Values[5])
+
+		result, err = tb.Find(ctx, nil)
+		require.NoError(ti, err)
+		var maps []map[string]interface{}
+		require.NoError(ti, result.All(&maps))
+		require.Len(ti, maps, 2)
+		require.Equal(ti, uint64(2), maps[1]["ID"])
+		require.Nil(ti, maps[1]["Extra"])
+	})
+
+	t.Run("Columns are not shared", func(ti *testing.T) {
+		result, err := tb.Find(ctx, nil)
+		require.NoError(ti, err)
+		var records []Record
+		require.NoError(ti, result.All(&records))
+		records[0].Columns[0] = "Key"
+		require.Equal(ti, "ID", records[1].Columns[0])
+		require.Equal(ti, "ID", result.Columns()[0])
+	})
+
+	t.Run("QueryStmt in transaction", func(ti *testing.T) {
+		tx, err := db.BeginTransaction(ctx)
+		require.NoError(ti, err)
+		defer tx.RollbackTransaction()
+
+		result, err := tx.QueryStmt(sql.Select().From("db", "users"))
+		require.NoError(ti, err)
+		var records []Record
+		require.NoError(ti, result.All(&records))
+		require.Len(ti, records, 2)
+		require.Equal(ti, record, records[0])
+	})
+}
+
+type joinedOrder struct {
+	ID     int64
+	Amount float64
+	User   struct {
+		ID   int64
+		Name string
+	}
+	Address *struct {
+		City string
+	}
+}
+
+func TestDecodeJoin(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	tb := db.Table("Order")
+	r.Columns = []string{"ID", "Amount", "User.ID", "User.Name", "Address.City"}
+	r.Rows = [][]driver.Value{
+		{int64(1), float64(10.5), int64(7), "john", "Kuala Lumpur"},
+		{int64(2), float64(3), int64(8), "doe", "Penang"},
+	}
+
+	act := actions.Find().
+		Select(
+			expr.Column("Order", "ID"),
+			expr.Column("Order", "Amount"),
+			expr.Qualified("User", "ID"),
+			expr.Qualified("User", "Name"),
+			expr.Qualified("Address", "City"),
+		).
+		InnerJoin("User").
+		On(expr.Equal(expr.Column("User", "ID"), expr.Column("Order", "UserID"))).
+		LeftJoin("Address").
+		On(expr.Equal(expr.Column("Address", "UserID"), expr.Column("User", "ID")))
+
+	t.Run("Decode", func(ti *testing.T) {
+		r.Stmts = nil
+		result, err := tb.Find(ctx, act)
+		require.NoError(ti, err)
+		require.Equal(ti, []string{
+			"SELECT `Order`.`ID`,`Order`.`Amount`,(`User`.`ID`) AS `User.ID`,(`User`.`Name`) AS `User.Name`,(`Address`.`City`) AS `Address.City` FROM `db`.`Order` INNER JOIN `db`.`User` ON `User`.`ID` = `Order`.`UserID` LEFT JOIN `db`.`Address` ON `Address`.`UserID` = `User`.`ID` LIMIT 100;",
+		}, r.Stmts)
+
+		require.True(ti, result.Next())
+		var o joinedOrder
+		require.NoError(ti, result.Decode(&o))
+		require.Equal(ti, int64(1), o.ID)
+		require.Equal(ti, float64(10.5), o.Amount)
+		require.Equal(ti, int64(7), o.User.ID)
+		require.Equal(ti, "john", o.User.Name)
+		require.NotNil(ti, o.Address)
+		require.Equal(ti, "Kuala Lumpur", o.Address.City)
+		require.NoError(ti, result.Close())
+	})
+
+	t.Run("All", func(ti *testing.T) {
+		result, err := tb.Find(ctx, act)
+		require.NoError(ti, err)
+		var orders []joinedOrder
+		require.NoError(ti, result.All(&orders))
+		require.Len(ti, orders, 2)
+		require.Equal(ti, int64(8), orders[1].User.ID)
+		require.Equal(ti, "doe", orders[1].User.Name)
+		require.Equal(ti, "Penang", orders[1].Address.City)
+	})
+}
@@ -0,0 +1,54 @@
+package sqlike
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+)
+
+const maxRetryBackoff = 5 * time.Second
+
+type retryCtxKey struct{}
+
+// RetryCount : the number of times the transaction has been retried, it's zero on the first attempt
+func RetryCount(ctx context.Context) uint {
+	v, _ := ctx.Value(retryCtxKey{}).(uint)
+	return v
+}
+
+func withRetryCount(ctx context.Context, count uint) context.Context {
+	if count < 1 {
+		return ctx
+	}
+	return context.WithValue(ctx, retryCtxKey{}, count)
+}
+
+// retryDelay : exponential backoff of the retry with jitter, so the conflicting transactions won't retry at the same time
+func retryDelay(backoff time.Duration, retry uint) time.Duration {
+	if backoff <= 0 {
+		return 0
+	}
+	delay := backoff
+	for i := uint(1); i < retry && delay < maxRetryBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryBackoff {
+		delay = maxRetryBackoff
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int64N(int64(delay-half)+1))
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,204 @@
+package sqlike
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+
+	_ "modernc.org/sqlite"
+)
+
+// driverUser : the entity which is used by the tests against real driver
+type driverUser struct {
+	ID        int64 `sqlike:",primary_key,auto_increment"`
+	Name      string
+	Email     string `sqlike:",unique_index"`
+	Age       int
+	Active    bool
+	Tags      []string
+	CreatedAt time.Time
+}
+
+func newSQLiteDatabase(t *testing.T) *Database {
+	ctx := context.Background()
+	client, err := Connect(
+		ctx, "sqlite",
+		options.Connect().ApplyURI(filepath.Join(t.TempDir(), "sqlike.db")),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close()
+	})
+	// the cursor of pagination is looked up by the primary key
+	return client.SetPrimaryKey("ID").Database("main")
+}
+
+func TestSQLite(t *testing.T) {
+	ctx := context.Background()
+	db := newSQLiteDatabase(t)
+	tb := db.Table("User")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	t.Run("Migrate", func(ti *testing.T) {
+		require.NoError(ti, tb.Migrate(ctx, driverUser{}))
+		require.True(ti, tb.Exists(ctx))
+		// migrate again should be no-op
+		require.NoError(ti, tb.Migrate(ctx, driverUser{}))
+
+		columns, err := tb.ListColumns(ctx)
+		require.NoError(ti, err)
+		names := make([]string, len(columns))
+		for i, col := range columns {
+			names[i] = col.Name
+		}
+		require.Equal(ti, []string{"ID", "Name", "Email", "Age", "Active", "Tags", "CreatedAt"}, names)
+	})
+
+	t.Run("Insert", func(ti *testing.T) {
+		users := make([]driverUser, 0, 10)
+		for i := 1; i <= 10; i++ {
+			users = append(users, driverUser{
+				Name:      "user",
+				Email:     "user" + string(rune('a'+i-1)) + "@sqlike.dev",
+				Age:       20 + i,
+				Active:    i%2 == 0,
+				Tags:      []string{"a", "b"},
+				CreatedAt: now,
+			})
+		}
+		result, err := tb.Insert(ctx, &users)
+		require.NoError(ti, err)
+		affected, err := result.RowsAffected()
+		require.NoError(ti, err)
+		require.Equal(ti, int64(10), affected)
+
+		// unique index is enforced
+		_, err = tb.InsertOne(ctx, &driverUser{Email: "usera@sqlike.dev", CreatedAt: now})
+		require.Error(ti, err)
+	})
+
+	t.Run("Find", func(ti *testing.T) {
+		var user driverUser
+		require.NoError(ti, tb.FindOne(ctx, actions.FindOne().Where(expr.Equal("ID", 1))).Decode(&user))
+		require.Equal(ti, driverUser{
+			ID:        1,
+			Name:      "user",
+			Email:     "usera@sqlike.dev",
+			Age:       21,
+			Tags:      []string{"a", "b"},
+			CreatedAt: now,
+		}, user)
+
+		result, err := tb.Find(
+			ctx,
+			actions.Find().
+				Where(
+					expr.Equal("Active", true),
+					expr.GreaterThan("Age", 22),
+				).
+				OrderBy(expr.Desc("Age")),
+		)
+		require.NoError(ti, err)
+		var users []driverUser
+		require.NoError(ti, result.All(&users))
+		require.Len(ti, users, 4)
+		require.Equal(ti, int64(10), users[0].ID)
+		require.Equal(ti, int64(4), users[3].ID)
+
+		err = tb.FindOne(ctx, actions.FindOne().Where(expr.Equal("ID", 100))).Decode(&user)
+		require.Equal(ti, ErrNoRows, err)
+	})
+
+	t.Run("Paginate", func(ti *testing.T) {
+		pg, err := tb.Paginate(ctx, actions.Paginate().OrderBy(expr.Desc("Age")).Limit(4))
+		require.NoError(ti, err)
+
+		ids := make([]int64, 0)
+		for {
+			var users []driverUser
+			require.NoError(ti, pg.All(&users))
+			if len(users) == 0 {
+				break
+			}
+			for _, u := range users {
+				ids = append(ids, u.ID)
+			}
+			if len(users) < 4 {
+				break
+			}
+			// the last record will be the first record of next page
+			ids = ids[:len(ids)-1]
+			require.NoError(ti, pg.NextCursor(ctx, users[len(users)-1].ID))
+		}
+		require.Equal(ti, []int64{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, ids)
+	})
+
+	t.Run("RunInTransaction", func(ti *testing.T) {
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			_, err := sess.Table("User").UpdateOne(
+				sess,
+				actions.UpdateOne().Where(expr.Equal("ID", 1)).Set(expr.ColumnValue("Name", "john")),
+			)
+			return err
+		})
+		require.NoError(ti, err)
+
+		errRollback := errors.New("rollback")
+		err = db.RunInTransaction(ctx, func(sess SessionContext) error {
+			if _, err := sess.Table("User").UpdateOne(
+				sess,
+				actions.UpdateOne().Where(expr.Equal("ID", 2)).Set(expr.ColumnValue("Name", "doe")),
+			); err != nil {
+				return err
+			}
+			return errRollback
+		})
+		require.Equal(ti, errRollback, err)
+
+		result, err := tb.Find(ctx, actions.Find().Where(expr.In("ID", []int64{1, 2})).OrderBy(expr.Asc("ID")))
+		require.NoError(ti, err)
+		var users []driverUser
+		require.NoError(ti, result.All(&users))
+		require.Equal(ti, "john", users[0].Name)
+		require.Equal(ti, "user", users[1].Name)
+	})
+
+	t.Run("Delete", func(ti *testing.T) {
+		affected, err := tb.Delete(ctx, actions.Delete().Where(expr.LesserOrEqual("Age", 25)).OrderBy(expr.Desc("Age")).Limit(2))
+		require.NoError(ti, err)
+		require.Equal(ti, int64(2), affected)
+
+		result, err := tb.Find(ctx, nil)
+		require.NoError(ti, err)
+		var users []driverUser
+		require.NoError(ti, result.All(&users))
+		require.Len(ti, users, 8)
+	})
+
+	t.Run("MigrationPlan", func(ti *testing.T) {
+		type user struct {
+			ID     int64 `sqlike:",primary_key,auto_increment"`
+			Name   string
+			Email  string `sqlike:",unique_index"`
+			Age    int
+			Active bool
+			Remark string
+		}
+		plan, err := tb.MigrationPlan(ctx, user{}, options.MigrationPlan().SetUnsafe(true))
+		require.NoError(ti, err)
+		require.True(ti, plan.Destructive())
+		// only the statements which drop the column are destructive
+		require.Equal(ti, []PlanStatement{
+			{SQL: `ALTER TABLE "main"."User" ADD COLUMN "Remark" TEXT NOT NULL DEFAULT ''`},
+			{SQL: `ALTER TABLE "main"."User" DROP COLUMN "Tags"`, Destructive: true, Drops: []string{`DROP COLUMN "Tags"`}},
+			{SQL: `ALTER TABLE "main"."User" DROP COLUMN "CreatedAt"`, Destructive: true, Drops: []string{`DROP COLUMN "CreatedAt"`}},
+		}, plan.Statements)
+	})
+}
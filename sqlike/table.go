@@ -13,7 +13,9 @@ import (
 	"github.com/si3nloong/sqlike/sql/dialect"
 	sqldriver "github.com/si3nloong/sqlike/sql/driver"
 	sqlstmt "github.com/si3nloong/sqlike/sql/stmt"
+	sqlutil "github.com/si3nloong/sqlike/sql/util"
 	"github.com/si3nloong/sqlike/sqlike/logs"
+	"github.com/si3nloong/sqlike/sqlike/options"
 )
 
 // ErrNoRecordAffected :
@@ -49,6 +51,11 @@ type Table struct {
 	logger logs.Logger
 }
 
+// executor : the operation will be executed within the transaction if the context carries one, see `WithTransaction`
+func (tb *Table) executor(ctx context.Context) sqldriver.Driver {
+	return executor(ctx, tb.client, tb.driver)
+}
+
 // Rename : rename the current table name to new table name
 func (tb *Table) Rename(ctx context.Context, name string) error {
 	stmt := sqlstmt.AcquireStmt(tb.dialect)
@@ -56,7 +63,7 @@ func (tb *Table) Rename(ctx context.Context, name string) error {
 	tb.dialect.RenameTable(stmt, tb.dbName, tb.name, name)
 	_, err := sqldriver.Execute(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	)
@@ -71,7 +78,7 @@ func (tb *Table) Exists(ctx context.Context) bool {
 	tb.dialect.HasTable(stmt, tb.dbName, tb.name)
 	if err := sqldriver.QueryRowContext(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	).Scan(&count); err != nil {
@@ -92,7 +99,7 @@ func (tb *Table) ListColumns(ctx context.Context) ([]Column, error) {
 	tb.dialect.GetColumns(stmt, tb.dbName, tb.name)
 	rows, err := sqldriver.Query(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	)
@@ -135,7 +142,7 @@ func (tb *Table) ListIndexes(ctx context.Context) ([]Index, error) {
 	tb.dialect.GetIndexes(stmt, tb.dbName, tb.name)
 	rows, err := sqldriver.Query(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	)
@@ -160,6 +167,52 @@ func (tb *Table) ListIndexes(ctx context.Context) ([]Index, error) {
 	return idxs, nil
 }
 
+// ListForeignKeys : list all the foreign keys of the table, the columns of the same foreign key will be grouped.
+func (tb *Table) ListForeignKeys(ctx context.Context) ([]ForeignKey, error) {
+	stmt := sqlstmt.AcquireStmt(tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	tb.dialect.GetForeignKeys(stmt, tb.dbName, tb.name)
+	rows, err := sqldriver.Query(
+		ctx,
+		tb.executor(ctx),
+		stmt,
+		tb.logger,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fks := make([]ForeignKey, 0)
+	for rows.Next() {
+		var name, col, refTable, refCol, onDelete, onUpdate string
+		if err := rows.Scan(
+			&name,
+			&col,
+			&refTable,
+			&refCol,
+			&onDelete,
+			&onUpdate,
+		); err != nil {
+			return nil, err
+		}
+		if n := len(fks); n > 0 && fks[n-1].Name == name {
+			fks[n-1].Columns = append(fks[n-1].Columns, col)
+			fks[n-1].RefColumns = append(fks[n-1].RefColumns, refCol)
+			continue
+		}
+		fks = append(fks, ForeignKey{
+			Name:       name,
+			Columns:    []string{col},
+			RefTable:   refTable,
+			RefColumns: []string{refCol},
+			OnDelete:   onDelete,
+			OnUpdate:   onUpdate,
+		})
+	}
+	return fks, rows.Err()
+}
+
 // MustMigrate : this will ensure the migrate is complete, otherwise it will panic
 func (tb Table) MustMigrate(ctx context.Context, entity interface{}) {
 	err := tb.Migrate(ctx, entity)
@@ -178,6 +231,16 @@ func (tb *Table) UnsafeMigrate(ctx context.Context, entity interface{}) error {
 	return tb.migrateOne(ctx, tb.client.cache, entity, true)
 }
 
+// MigrateStatements : return the statements which `Migrate` (or `UnsafeMigrate` if unsafe is true) will execute, without executing them
+func (tb *Table) MigrateStatements(ctx context.Context, entity interface{}, unsafe bool) ([]string, error) {
+	stmt := sqlstmt.AcquireStmt(tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	if err := tb.buildMigrateStmt(ctx, stmt, tb.client.cache, entity, unsafe); err != nil {
+		return nil, err
+	}
+	return sqlutil.SplitStatements(stmt.String()), nil
+}
+
 // MustUnsafeMigrate : this will panic if it get error on unsafe migrate
 func (tb *Table) MustUnsafeMigrate(ctx context.Context, entity interface{}) {
 	err := tb.migrateOne(ctx, tb.client.cache, entity, true)
@@ -193,7 +256,7 @@ func (tb *Table) Truncate(ctx context.Context) (err error) {
 	tb.dialect.TruncateTable(stmt, tb.dbName, tb.name)
 	_, err = sqldriver.Execute(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	)
@@ -207,7 +270,7 @@ func (tb Table) DropIfExists(ctx context.Context) (err error) {
 	tb.dialect.DropTable(stmt, tb.dbName, tb.name, true)
 	_, err = sqldriver.Execute(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	)
@@ -221,7 +284,7 @@ func (tb Table) Drop(ctx context.Context) (err error) {
 	tb.dialect.DropTable(stmt, tb.dbName, tb.name, false)
 	_, err = sqldriver.Execute(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	)
@@ -244,7 +307,7 @@ func (tb *Table) Replace(ctx context.Context, fields []string, query *sql.Select
 
 	if _, err := sqldriver.Execute(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	); err != nil {
@@ -253,11 +316,56 @@ func (tb *Table) Replace(ctx context.Context, fields []string, query *sql.Select
 	return nil
 }
 
+// InsertFrom : insert the rows selected by the query, `INSERT INTO ... SELECT`. It returns the number of affected rows.
+// Only the option of mode, omit fields and on conflict is applicable, and the inserted columns (except primary key and omitted fields)
+// will be updated on conflict by default.
+func (tb *Table) InsertFrom(ctx context.Context, columns []string, query *sql.SelectStmt, opts ...*options.InsertOptions) (int64, error) {
+	opt := new(options.InsertOptions)
+	if len(opts) > 0 && opts[0] != nil {
+		opt = opts[0]
+	}
+	if query == nil {
+		return 0, errors.New("sqlike: empty query statement")
+	}
+	if opt.Mode == options.InsertOnDuplicate && len(columns) < 1 &&
+		(opt.OnConflict == nil || (len(opt.OnConflict.Columns) < 1 && len(opt.OnConflict.Values) < 1)) {
+		return 0, ErrNoValueUpdate
+	}
+	stmt := sqlstmt.AcquireStmt(tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	if err := tb.dialect.InsertFrom(
+		stmt,
+		tb.dbName,
+		tb.name,
+		tb.pk,
+		columns,
+		query,
+		opt,
+	); err != nil {
+		return 0, err
+	}
+	result, err := sqldriver.Execute(
+		ctx,
+		tb.executor(ctx),
+		stmt,
+		getLogger(tb.logger, opt.Debug),
+	)
+	if err != nil {
+		return 0, tb.dialect.MapError(err)
+	}
+	return result.RowsAffected()
+}
+
 // Indexes :
 func (tb *Table) Indexes() *IndexView {
 	return &IndexView{tb: tb}
 }
 
+// ForeignKeys :
+func (tb *Table) ForeignKeys() *ForeignKeyView {
+	return &ForeignKeyView{tb: tb}
+}
+
 // HasIndexByName :
 func (tb *Table) HasIndexByName(ctx context.Context, name string) (bool, error) {
 	return isIndexExists(
@@ -265,31 +373,49 @@ func (tb *Table) HasIndexByName(ctx context.Context, name string) (bool, error)
 		tb.dbName,
 		tb.name,
 		name,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
 		tb.logger,
 	)
 }
 
 func (tb *Table) migrateOne(ctx context.Context, cache reflext.StructMapper, entity interface{}, unsafe bool) error {
-	v := reflext.ValueOf(entity)
-	if !v.IsValid() {
-		return ErrInvalidInput
+	stmt := sqlstmt.AcquireStmt(tb.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	if err := tb.buildMigrateStmt(ctx, stmt, cache, entity, unsafe); err != nil {
+		return err
 	}
-
-	t := reflext.Deref(v.Type())
-	if !reflext.IsKind(t, reflect.Struct) {
-		return ErrExpectedStruct
+	// nothing to migrate
+	if stmt.String() == "" {
+		return nil
+	}
+	if _, err := sqldriver.Execute(
+		ctx,
+		tb.executor(ctx),
+		stmt,
+		tb.logger,
+	); err != nil {
+		return tb.dialect.MapError(err)
 	}
+	return nil
+}
 
-	cdc := cache.CodecByType(t)
-	fields := skipColumns(cdc.Properties(), nil)
-	if len(fields) < 1 {
-		return ErrEmptyFields
+// buildMigrateStmt : build the create or alter table statement without executing it, the statement will be empty if nothing to alter
+func (tb *Table) buildMigrateStmt(ctx context.Context, stmt *sqlstmt.Statement, cache reflext.StructMapper, entity interface{}, unsafe bool) error {
+	fields, err := entityFields(cache, entity)
+	if err != nil {
+		return err
 	}
 
 	if !tb.Exists(ctx) {
-		return tb.createTable(ctx, fields)
+		return tb.dialect.CreateTable(
+			stmt,
+			tb.dbName,
+			tb.name,
+			tb.pk,
+			tb.client.DriverInfo,
+			fields,
+		)
 	}
 
 	columns, err := tb.ListColumns(ctx)
@@ -300,34 +426,34 @@ func (tb *Table) migrateOne(ctx context.Context, cache reflext.StructMapper, ent
 	if err != nil {
 		return err
 	}
-	return tb.alterTable(ctx, fields, columns, idxs, unsafe)
+	fks, err := tb.ListForeignKeys(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = tb.buildAlterTable(ctx, stmt, fields, columns, idxs, fks, unsafe)
+	return err
 }
 
-func (tb *Table) createTable(ctx context.Context, fields []reflext.StructFielder) error {
-	stmt := sqlstmt.AcquireStmt(tb.dialect)
-	defer sqlstmt.ReleaseStmt(stmt)
-	if err := tb.dialect.CreateTable(
-		stmt,
-		tb.dbName,
-		tb.name,
-		tb.pk,
-		tb.client.DriverInfo,
-		fields,
-	); err != nil {
-		return err
+func entityFields(cache reflext.StructMapper, entity interface{}) ([]reflext.StructFielder, error) {
+	v := reflext.ValueOf(entity)
+	if !v.IsValid() {
+		return nil, ErrInvalidInput
 	}
-	if _, err := sqldriver.Execute(
-		ctx,
-		tb.driver,
-		stmt,
-		tb.logger,
-	); err != nil {
-		return err
+
+	t := reflext.Deref(v.Type())
+	if !reflext.IsKind(t, reflect.Struct) {
+		return nil, ErrExpectedStruct
 	}
-	return nil
+
+	cdc := cache.CodecByType(t)
+	fields := skipColumns(cdc.Properties(), nil)
+	if len(fields) < 1 {
+		return nil, ErrEmptyFields
+	}
+	return fields, nil
 }
 
-func (tb *Table) alterTable(ctx context.Context, fields []reflext.StructFielder, columns []Column, indexs []Index, unsafe bool) error {
+func (tb *Table) buildAlterTable(ctx context.Context, stmt *sqlstmt.Statement, fields []reflext.StructFielder, columns []Column, indexs []Index, fks []ForeignKey, unsafe bool) ([]string, error) {
 	cols := make([]string, len(columns))
 	for i, col := range columns {
 		cols[i] = col.Name
@@ -336,34 +462,25 @@ func (tb *Table) alterTable(ctx context.Context, fields []reflext.StructFielder,
 	for i, idx := range indexs {
 		idxs[i] = idx.Name
 	}
-	stmt := sqlstmt.AcquireStmt(tb.dialect)
-	defer sqlstmt.ReleaseStmt(stmt)
 	tb.dialect.HasPrimaryKey(stmt, tb.dbName, tb.name)
 	var count uint
 	if err := sqldriver.QueryRowContext(
 		ctx,
-		tb.driver,
+		tb.executor(ctx),
 		stmt,
 		tb.logger,
 	).Scan(&count); err != nil {
-		return err
+		return nil, err
 	}
 	stmt.Reset()
-	if err := tb.dialect.AlterTable(
+	fkNames, err := foreignKeyNames(tb.name, fields, fks)
+	if err != nil {
+		return nil, err
+	}
+	return tb.dialect.AlterTable(
 		stmt,
 		tb.dbName, tb.name, tb.pk, count > 0,
 		tb.client.DriverInfo,
-		fields, cols, idxs, unsafe,
-	); err != nil {
-		return err
-	}
-	if _, err := sqldriver.Execute(
-		ctx,
-		tb.driver,
-		stmt,
-		tb.logger,
-	); err != nil {
-		return err
-	}
-	return nil
+		fields, cols, idxs, fkNames, unsafe,
+	)
 }
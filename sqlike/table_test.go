@@ -0,0 +1,89 @@
+package sqlike
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	semver "github.com/Masterminds/semver/v3"
+	"github.com/si3nloong/sqlike/reflext"
+	"github.com/si3nloong/sqlike/sql"
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike/foreignkeys"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertFrom(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	tb := db.Table("users")
+	tb.pk = "ID"
+
+	query := sql.Select("Email", "Count").
+		From("db", "archived_users").
+		Where(expr.GreaterThan("Count", 0))
+
+	_, err := tb.InsertFrom(ctx, []string{"Email", "Count"}, query, options.Insert().SetMode(options.InsertIgnore))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"INSERT IGNORE INTO `db`.`users` (`Email`,`Count`) SELECT `Email`,`Count` FROM `db`.`archived_users` WHERE `Count` > ?;",
+	}, r.Stmts)
+
+	// every inserted column except primary key and omitted fields is updated by default
+	r.Stmts = nil
+	_, err = tb.InsertFrom(ctx, []string{"ID", "Email", "Count", "CreatedAt"}, sql.Select("ID", "Email", "Count", "CreatedAt").From("db", "archived_users"),
+		options.Insert().SetMode(options.InsertOnDuplicate).SetOmitFields("CreatedAt"))
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"INSERT INTO `db`.`users` (`ID`,`Email`,`Count`,`CreatedAt`) SELECT `ID`,`Email`,`Count`,`CreatedAt` FROM `db`.`archived_users` ON DUPLICATE KEY UPDATE `Email`=VALUES(`Email`),`Count`=VALUES(`Count`);",
+	}, r.Stmts)
+
+	// nothing to update on duplicate
+	_, err = tb.InsertFrom(ctx, nil, query, options.Insert().SetMode(options.InsertOnDuplicate))
+	require.Equal(t, ErrNoValueUpdate, err)
+
+	_, err = tb.InsertFrom(ctx, nil, nil)
+	require.Error(t, err)
+	require.Len(t, r.Stmts, 1)
+}
+
+func TestInsertRowAlias(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	tb := db.Table("users")
+	opt := options.InsertOne().SetOnConflict(options.OnConflict().SetRowAlias("new"))
+
+	db.client.version = semver.MustParse("5.7.30")
+	_, err := tb.InsertOne(ctx, &typedUser{ID: 1, Name: "john"}, opt)
+	require.EqualError(t, err, "mysql: row alias of on duplicate key update is not supported on version 5.7.30, it requires 8.0.19 or above")
+	require.Empty(t, r.Stmts)
+
+	db.client.version = semver.MustParse("8.0.19")
+	_, err = tb.InsertOne(ctx, &typedUser{ID: 1, Name: "john"}, opt)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"INSERT INTO `db`.`users` (`ID`,`Name`) VALUES (?,?) AS `new` ON DUPLICATE KEY UPDATE `Name`=`new`.`Name`;",
+	}, r.Stmts)
+}
+
+func TestForeignKeyNames(t *testing.T) {
+	type post struct {
+		ID     int64 `sqlike:",primary_key"`
+		UserID int64 `sqlike:",foreign_key=users.ID,on_delete=cascade"`
+	}
+	fields := reflext.DefaultMapper.CodecByType(reflect.TypeOf(post{})).Properties()
+	fks, err := foreignkeys.FromFields(fields)
+	require.NoError(t, err)
+	name := fks[0].GetName("posts")
+
+	names, err := foreignKeyNames("posts", fields, []ForeignKey{
+		// sqlite names the foreign key by its id
+		{Name: "0", Columns: []string{"UserID"}, RefTable: "users", RefColumns: []string{"ID"}, OnDelete: "CASCADE", OnUpdate: "NO ACTION"},
+		{Name: "1", Columns: []string{"UserID"}, RefTable: "users", RefColumns: []string{"ID"}, OnDelete: "SET NULL"},
+		// the managed foreign key is always identified by its name
+		{Name: "FK_outdated", Columns: []string{"UserID"}, RefTable: "users", RefColumns: []string{"ID"}, OnDelete: "CASCADE"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{name, "1", "FK_outdated"}, names)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"log"
+	"strconv"
 
 	"github.com/si3nloong/sqlike/sql/codec"
 	"github.com/si3nloong/sqlike/sql/dialect"
@@ -21,6 +24,47 @@ type SessionContext interface {
 	Query(query string, args ...interface{}) (*sql.Rows, error)
 	QueryRow(query string, args ...interface{}) *sql.Row
 	QueryStmt(query interface{}) (*Result, error)
+	RunInTransaction(cb txCallback) error
+	Savepoint(name string) error
+	RollbackToSavepoint(name string) error
+	ReleaseSavepoint(name string) error
+	OnCommit(fn func(ctx context.Context) error)
+	OnRollback(fn func(ctx context.Context) error)
+}
+
+type txCtxKey struct{}
+
+// WithTransaction : returns a copy of context which carries the transaction, the table operations (eg. `InsertOne`, `Find`, `ModifyOne`)
+// of the same client will be executed within the transaction when they receive the context
+func WithTransaction(ctx context.Context, tx *Transaction) context.Context {
+	return context.WithValue(ctx, txCtxKey{}, tx)
+}
+
+// TransactionFromContext : returns the transaction carried by the context
+func TransactionFromContext(ctx context.Context) (*Transaction, bool) {
+	tx, ok := ctx.Value(txCtxKey{}).(*Transaction)
+	return tx, ok && tx != nil
+}
+
+// activeTransaction : the transaction in context which belongs to the client and not yet ended
+func activeTransaction(ctx context.Context, client *Client) (*Transaction, bool) {
+	tx, ok := TransactionFromContext(ctx)
+	if !ok || tx.client != client || tx.done {
+		return nil, false
+	}
+	return tx, true
+}
+
+// executor : join the transaction in context, only the operation on the primary connection pool will join,
+// so the operation of table which is bound to another transaction stays on its own transaction
+func executor(ctx context.Context, client *Client, drv driver.Driver) driver.Driver {
+	if db, ok := drv.(*sql.DB); !ok || db != client.DB {
+		return drv
+	}
+	if tx, ok := activeTransaction(ctx, client); ok {
+		return tx.driver
+	}
+	return drv
 }
 
 // Transaction :
@@ -38,6 +82,14 @@ type Transaction struct {
 	dialect dialect.Dialect
 	codec   codec.Codecer
 	logger  logs.Logger
+
+	// number of savepoints created by nested transaction, it's used to name the savepoint
+	savepoints int
+
+	// callbacks which will be executed after the transaction is ended
+	onCommit   []func(ctx context.Context) error
+	onRollback []func(ctx context.Context) error
+	done       bool
 }
 
 // Prepare : PrepareContext creates a prepared statement for use within a transaction.
@@ -95,19 +147,154 @@ func (tx *Transaction) QueryStmt(query interface{}) (*Result, error) {
 		return nil, err
 	}
 	rslt := new(Result)
+	rslt.ctx = tx
 	rslt.cache = tx.client.cache
 	rslt.codec = tx.codec
 	rslt.rows = rows
-	rslt.columns, rslt.err = rows.Columns()
+	rslt.columnTypes, rslt.err = rows.ColumnTypes()
+	if rslt.err != nil {
+		defer rslt.rows.Close()
+	}
+	for _, col := range rslt.columnTypes {
+		rslt.columns = append(rslt.columns, col.Name())
+	}
 	return rslt, rslt.err
 }
 
+// RunInTransaction : run the callback within a savepoint of current transaction, the savepoint will be rolled back if the callback returns error,
+// so the failure of nested transaction only discards its own work and the outer transaction is able to continue.
+func (tx *Transaction) RunInTransaction(cb txCallback) error {
+	tx.savepoints++
+	name := "sqlike_sp_" + strconv.Itoa(tx.savepoints)
+	if err := tx.Savepoint(name); err != nil {
+		return err
+	}
+	commits, rollbacks := len(tx.onCommit), len(tx.onRollback)
+	if err := cb(tx); err != nil {
+		if rbErr := tx.RollbackToSavepoint(name); rbErr != nil {
+			return rbErr
+		}
+		// the work of nested transaction is discarded, so are its commit callbacks,
+		// and its rollback callbacks are executed right away
+		tx.onCommit = tx.onCommit[:commits]
+		fns := tx.onRollback[rollbacks:]
+		tx.onRollback = tx.onRollback[:rollbacks]
+		tx.runCallbacks(fns)
+		// the savepoint still exists after rollback, so release it as well
+		if rlErr := tx.ReleaseSavepoint(name); rlErr != nil {
+			return rlErr
+		}
+		return err
+	}
+	return tx.ReleaseSavepoint(name)
+}
+
+// Savepoint : create a savepoint with the name within the transaction.
+func (tx *Transaction) Savepoint(name string) error {
+	return tx.execSavepoint(tx.dialect.Savepoint, name)
+}
+
+// RollbackToSavepoint : rollback the work done after the savepoint is created, the savepoint remains.
+func (tx *Transaction) RollbackToSavepoint(name string) error {
+	return tx.execSavepoint(tx.dialect.RollbackToSavepoint, name)
+}
+
+// ReleaseSavepoint : remove the savepoint, the work done after the savepoint will be kept.
+func (tx *Transaction) ReleaseSavepoint(name string) error {
+	return tx.execSavepoint(tx.dialect.ReleaseSavepoint, name)
+}
+
+func (tx *Transaction) execSavepoint(build func(stmt sqlstmt.Stmt, name string), name string) error {
+	if name == "" {
+		return errors.New("sqlike: empty savepoint name")
+	}
+	stmt := sqlstmt.AcquireStmt(tx.dialect)
+	defer sqlstmt.ReleaseStmt(stmt)
+	build(stmt, name)
+	_, err := driver.Execute(
+		tx,
+		tx.driver,
+		stmt,
+		tx.logger,
+	)
+	return err
+}
+
+// OnCommit : register a callback which will be executed after the transaction is committed, callbacks are executed in the order of registration.
+// The context of callback no longer joins the transaction, and the error returned is reported to the handler of `SetTxCallbackErrorHandler`.
+func (tx *Transaction) OnCommit(fn func(ctx context.Context) error) {
+	if fn == nil {
+		return
+	}
+	tx.onCommit = append(tx.onCommit, fn)
+}
+
+// OnRollback : register a callback which will be executed after the transaction is rolled back, including the failure of commit.
+// The error returned is reported to the handler of `SetTxCallbackErrorHandler` as well.
+func (tx *Transaction) OnRollback(fn func(ctx context.Context) error) {
+	if fn == nil {
+		return
+	}
+	tx.onRollback = append(tx.onRollback, fn)
+}
+
 // RollbackTransaction : Rollback aborts the transaction.
 func (tx *Transaction) RollbackTransaction() error {
-	return tx.driver.Rollback()
+	err := tx.driver.Rollback()
+	if !tx.done {
+		// the transaction is rolled back by the driver when the context is done, even though `Rollback` returns error
+		tx.end(tx.onRollback)
+	}
+	return err
 }
 
 // CommitTransaction : Commit commits the transaction.
 func (tx *Transaction) CommitTransaction() error {
-	return tx.driver.Commit()
+	if err := tx.driver.Commit(); err != nil {
+		if !tx.done {
+			tx.end(tx.onRollback)
+		}
+		return err
+	}
+	tx.end(tx.onCommit)
+	return nil
+}
+
+// end : the callbacks are only executed once, regardless of how many times the transaction is ended
+func (tx *Transaction) end(fns []func(ctx context.Context) error) {
+	tx.done = true
+	tx.onCommit, tx.onRollback = nil, nil
+	tx.runCallbacks(fns)
+}
+
+func (tx *Transaction) runCallbacks(fns []func(ctx context.Context) error) {
+	for _, fn := range fns {
+		tx.runCallback(fn)
+	}
+}
+
+// runCallback : the error and panic of callback are isolated, so the subsequent callbacks are still executed
+func (tx *Transaction) runCallback(fn func(ctx context.Context) error) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		err, ok := r.(error)
+		if !ok {
+			err = fmt.Errorf("%v", r)
+		}
+		tx.handleCallbackError(fmt.Errorf("sqlike: panic in transaction callback: %w", err))
+	}()
+	if err := fn(tx); err != nil {
+		tx.handleCallbackError(err)
+	}
+}
+
+func (tx *Transaction) handleCallbackError(err error) {
+	if tx.client != nil && tx.client.txCallbackErrorHandler != nil {
+		tx.client.txCallbackErrorHandler(tx, err)
+		return
+	}
+	log.Println(err)
 }
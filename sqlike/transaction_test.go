@@ -0,0 +1,242 @@
+package sqlike
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/si3nloong/sqlike/sqlike/options"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNestedTransaction(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+
+	err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+		require.NoError(t, sess.RunInTransaction(func(sess SessionContext) error {
+			return nil
+		}))
+		err := sess.RunInTransaction(func(sess SessionContext) error {
+			return errors.New("inner failed")
+		})
+		require.EqualError(t, err, "inner failed")
+		require.Error(t, sess.Savepoint(""))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"BEGIN",
+		"SAVEPOINT `sqlike_sp_1`;",
+		"RELEASE SAVEPOINT `sqlike_sp_1`;",
+		"SAVEPOINT `sqlike_sp_2`;",
+		"ROLLBACK TO SAVEPOINT `sqlike_sp_2`;",
+		"RELEASE SAVEPOINT `sqlike_sp_2`;",
+		"COMMIT",
+	}, r.Stmts)
+}
+
+func TestTransactionRetry(t *testing.T) {
+	ctx := context.Background()
+	deadlock := &gomysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"}
+
+	t.Run("Retry until succeed", func(ti *testing.T) {
+		db, r := newRecorderDatabase()
+		counts, retries := []uint{}, []uint{}
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			counts = append(counts, RetryCount(sess))
+			if len(counts) < 3 {
+				return deadlock
+			}
+			return nil
+		}, options.Transaction().
+			SetRetry(3, time.Millisecond).
+			SetOnRetry(func(ctx context.Context, attempt uint, err error) {
+				require.Equal(ti, deadlock, err)
+				retries = append(retries, attempt)
+			}))
+		require.NoError(ti, err)
+		require.Equal(ti, []uint{0, 1, 2}, counts)
+		require.Equal(ti, []uint{1, 2}, retries)
+		require.Equal(ti, []string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK", "BEGIN", "COMMIT"}, r.Stmts)
+	})
+
+	t.Run("Exceed maximum attempts", func(ti *testing.T) {
+		db, r := newRecorderDatabase()
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			return deadlock
+		}, options.Transaction().SetRetry(2, time.Millisecond))
+		require.Equal(ti, deadlock, err)
+		require.Equal(ti, []string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK"}, r.Stmts)
+	})
+
+	t.Run("Non retryable error", func(ti *testing.T) {
+		db, r := newRecorderDatabase()
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			return &gomysql.MySQLError{Number: 1062, Message: "Duplicate entry"}
+		}, options.Transaction().SetRetry(5, time.Millisecond))
+		require.Error(ti, err)
+		require.Equal(ti, []string{"BEGIN", "ROLLBACK"}, r.Stmts)
+	})
+
+	t.Run("Retry is disabled by default", func(ti *testing.T) {
+		db, r := newRecorderDatabase()
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			return deadlock
+		})
+		require.Equal(ti, deadlock, err)
+		require.Equal(ti, []string{"BEGIN", "ROLLBACK"}, r.Stmts)
+	})
+
+	t.Run("Backoff", func(ti *testing.T) {
+		require.Equal(ti, time.Duration(0), retryDelay(0, 1))
+		for i := 0; i < 10; i++ {
+			d := retryDelay(time.Millisecond*100, 3)
+			require.True(ti, d >= time.Millisecond*200 && d <= time.Millisecond*400)
+		}
+		require.True(ti, retryDelay(time.Second, 100) <= maxRetryBackoff)
+	})
+}
+
+func appendEvent(events *[]string, event string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		*events = append(*events, event)
+		return nil
+	}
+}
+
+func TestTransactionCallbacks(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("OnCommit", func(ti *testing.T) {
+		db, _ := newRecorderDatabase()
+		var (
+			events      []string
+			errs        []error
+			errCallback = errors.New("callback failed")
+		)
+		db.client.SetTxCallbackErrorHandler(func(ctx context.Context, err error) {
+			errs = append(errs, err)
+		})
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			sess.OnCommit(appendEvent(&events, "commit-1"))
+			sess.OnCommit(func(ctx context.Context) error { panic("boom") })
+			sess.OnCommit(func(ctx context.Context) error {
+				// the callback is executed after the transaction is ended
+				_, ok := activeTransaction(ctx, db.client)
+				require.False(ti, ok)
+				return errCallback
+			})
+			sess.OnCommit(appendEvent(&events, "commit-2"))
+			sess.OnRollback(appendEvent(&events, "rollback"))
+			require.Empty(ti, events)
+			return nil
+		})
+		require.NoError(ti, err)
+		require.Equal(ti, []string{"commit-1", "commit-2"}, events)
+		require.Equal(ti, 2, len(errs))
+		require.EqualError(ti, errs[0], "sqlike: panic in transaction callback: boom")
+		require.Equal(ti, errCallback, errs[1])
+	})
+
+	t.Run("OnRollback", func(ti *testing.T) {
+		db, _ := newRecorderDatabase()
+		events := []string{}
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			sess.OnCommit(appendEvent(&events, "commit"))
+			sess.OnRollback(appendEvent(&events, "rollback-1"))
+			sess.OnRollback(appendEvent(&events, "rollback-2"))
+			return errors.New("failed")
+		})
+		require.EqualError(ti, err, "failed")
+		require.Equal(ti, []string{"rollback-1", "rollback-2"}, events)
+	})
+
+	t.Run("Commit failed", func(ti *testing.T) {
+		db, r := newRecorderDatabase()
+		r.CommitErr = errors.New("commit failed")
+		events := []string{}
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			sess.OnCommit(appendEvent(&events, "commit"))
+			sess.OnRollback(appendEvent(&events, "rollback"))
+			return nil
+		})
+		require.Error(ti, err)
+		require.Equal(ti, []string{"rollback"}, events)
+	})
+
+	t.Run("Nested transaction", func(ti *testing.T) {
+		db, _ := newRecorderDatabase()
+		events := []string{}
+		err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+			sess.OnCommit(appendEvent(&events, "outer"))
+			require.NoError(ti, sess.RunInTransaction(func(sess SessionContext) error {
+				sess.OnCommit(appendEvent(&events, "inner-1"))
+				return nil
+			}))
+			require.Error(ti, sess.RunInTransaction(func(sess SessionContext) error {
+				sess.OnCommit(appendEvent(&events, "inner-2"))
+				sess.OnRollback(appendEvent(&events, "inner-2-rollback"))
+				return errors.New("inner failed")
+			}))
+			require.Equal(ti, []string{"inner-2-rollback"}, events)
+			return nil
+		})
+		require.NoError(ti, err)
+		require.Equal(ti, []string{"inner-2-rollback", "outer", "inner-1"}, events)
+	})
+}
+
+func TestTransactionContext(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+
+	_, ok := TransactionFromContext(ctx)
+	require.False(t, ok)
+	require.Equal(t, db.driver, db.Table("users").executor(ctx))
+
+	var ended context.Context
+	err := db.RunInTransaction(ctx, func(sess SessionContext) error {
+		ended = sess
+		tx, ok := TransactionFromContext(sess)
+		require.True(t, ok)
+		require.Equal(t, sess, tx)
+
+		// the context derived from transaction joins the transaction as well
+		c, cancel := context.WithCancel(sess)
+		defer cancel()
+		require.Equal(t, tx.driver, db.Table("users").executor(c))
+		require.Equal(t, tx.driver, db.Table("users").executor(WithTransaction(ctx, tx)))
+
+		// the table of transaction stays on its own transaction
+		tb := sess.Table("users")
+		require.Equal(t, tx.driver, tb.executor(ctx))
+
+		// the transaction of another client is ignored
+		other, _ := newRecorderDatabase()
+		require.Equal(t, other.driver, other.Table("users").executor(c))
+
+		// the options of nested transaction are decided by the outermost transaction
+		err := db.RunInTransaction(c, func(sess SessionContext) error {
+			return nil
+		}, options.Transaction().SetIsolationLevel(options.LevelSerializable))
+		require.Equal(t, ErrNestedTransactionOptions, err)
+
+		// the transaction in context becomes nested transaction
+		return db.RunInTransaction(c, func(sess SessionContext) error {
+			return nil
+		}, options.Transaction())
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"BEGIN",
+		"SAVEPOINT `sqlike_sp_1`;",
+		"RELEASE SAVEPOINT `sqlike_sp_1`;",
+		"COMMIT",
+	}, r.Stmts)
+
+	// the ended transaction is ignored
+	require.Equal(t, db.driver, db.Table("users").executor(ended))
+}
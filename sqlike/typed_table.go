@@ -0,0 +1,108 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/si3nloong/sqlike/sqlike/options"
+)
+
+// TypedTable : a table bound with the entity type, so the entity is checked on compile time instead of runtime
+type TypedTable[T any] struct {
+	tb *Table
+	// the field with `primary_key` tag, or the default primary key of table
+	pk string
+}
+
+// NewTypedTable : the entity must be a struct, otherwise it will return `ErrExpectedStruct`
+//
+//	users, err := sqlike.NewTypedTable[User](db.Table("User"))
+func NewTypedTable[T any](tb *Table) (*TypedTable[T], error) {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil, ErrExpectedStruct
+	}
+	pk := tb.pk
+	for _, sf := range tb.client.cache.CodecByType(t).Properties() {
+		if _, ok := sf.Tag().LookUp("primary_key"); ok {
+			pk = sf.Name()
+			break
+		}
+	}
+	return &TypedTable[T]{tb: tb, pk: pk}, nil
+}
+
+// Table : the underlying table, for the operations which are not entity specific
+func (t *TypedTable[T]) Table() *Table {
+	return t.tb
+}
+
+// InsertOne :
+func (t *TypedTable[T]) InsertOne(ctx context.Context, src *T, opts ...*options.InsertOneOptions) (sql.Result, error) {
+	return t.tb.InsertOne(ctx, src, opts...)
+}
+
+// FindOne : it will return `sql.ErrNoRows` if there is no record
+func (t *TypedTable[T]) FindOne(ctx context.Context, act actions.SelectOneStatement, opts ...*options.FindOneOptions) (*T, error) {
+	result := new(T)
+	if err := t.tb.FindOne(ctx, act, opts...).Decode(result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Find :
+func (t *TypedTable[T]) Find(ctx context.Context, act actions.SelectStatement, opts ...*options.FindOptions) ([]T, error) {
+	result, err := t.tb.Find(ctx, act, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var results []T
+	if err := result.All(&results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// Paginate : the page will start from the cursor if it's not nil, the returned cursor will be nil if there is no next page.
+// The records are paginated by the field with `primary_key` tag.
+//
+//	users, cursor, err := tb.Paginate(ctx, actions.Paginate().Limit(10), nil)
+//	users, cursor, err = tb.Paginate(ctx, actions.Paginate().Limit(10), cursor)
+func (t *TypedTable[T]) Paginate(ctx context.Context, act actions.PaginateStatement, cursor interface{}, opts ...*options.PaginateOptions) ([]T, interface{}, error) {
+	// the cursor record is inclusive, so we fetch one more record as the cursor of next page
+	opt := options.Paginate()
+	if len(opts) > 0 && opts[0] != nil {
+		o := *opts[0]
+		opt = &o
+	}
+	pg, err := t.tb.paginate(ctx, t.pk, act, opt.SetLookAhead(true))
+	if err != nil {
+		return nil, nil, err
+	}
+	if cursor != nil {
+		if err := pg.NextCursor(ctx, cursor); err != nil {
+			return nil, nil, err
+		}
+	}
+	limit := int(pg.limit)
+	var results []T
+	if err := pg.All(&results); err != nil {
+		return nil, nil, err
+	}
+	if len(results) <= limit {
+		return results, nil, nil
+	}
+	fv, ok := t.tb.client.cache.LookUpFieldByName(reflect.ValueOf(&results[limit]), t.pk)
+	if !ok {
+		return nil, nil, ErrInvalidCursor
+	}
+	return results[:limit], fv.Interface(), nil
+}
+
+// ModifyOne :
+func (t *TypedTable[T]) ModifyOne(ctx context.Context, update *T, opts ...*options.ModifyOneOptions) error {
+	return t.tb.ModifyOne(ctx, update, opts...)
+}
@@ -0,0 +1,87 @@
+package sqlike
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/si3nloong/sqlike/sql/expr"
+	"github.com/si3nloong/sqlike/sqlike/actions"
+	"github.com/stretchr/testify/require"
+)
+
+type typedUser struct {
+	ID   int64 `sqlike:",primary_key"`
+	Name string
+}
+
+func TestTypedTable(t *testing.T) {
+	ctx := context.Background()
+	db, r := newRecorderDatabase()
+	users, err := NewTypedTable[typedUser](db.Table("users"))
+	require.NoError(t, err)
+
+	_, err = NewTypedTable[string](db.Table("users"))
+	require.Equal(t, ErrExpectedStruct, err)
+	_, err = NewTypedTable[*typedUser](db.Table("users"))
+	require.Equal(t, ErrExpectedStruct, err)
+
+	t.Run("InsertOne & ModifyOne", func(ti *testing.T) {
+		r.Stmts = nil
+		_, err := users.InsertOne(ctx, &typedUser{ID: 1, Name: "john"})
+		require.NoError(ti, err)
+		_, err = users.InsertOne(ctx, nil)
+		require.Equal(ti, ErrNilEntity, err)
+		// the recorder doesn't affect any rows
+		err = users.ModifyOne(ctx, &typedUser{ID: 1, Name: "doe"})
+		require.Equal(ti, ErrNoRecordAffected, err)
+		require.Equal(ti, []string{
+			"INSERT INTO `db`.`users` (`ID`,`Name`) VALUES (?,?);",
+			"UPDATE `db`.`users` SET `Name` = ? WHERE `ID` = ? LIMIT 1;",
+		}, r.Stmts)
+	})
+
+	t.Run("FindOne & Find", func(ti *testing.T) {
+		r.Columns = []string{"ID", "Name"}
+		r.Rows = [][]driver.Value{{int64(1), "john"}, {int64(2), "doe"}}
+		user, err := users.FindOne(ctx, actions.FindOne().Where(expr.Equal("ID", 1)))
+		require.NoError(ti, err)
+		require.Equal(ti, &typedUser{ID: 1, Name: "john"}, user)
+
+		result, err := users.Find(ctx, nil)
+		require.NoError(ti, err)
+		require.Equal(ti, []typedUser{{ID: 1, Name: "john"}, {ID: 2, Name: "doe"}}, result)
+
+		r.Rows = nil
+		_, err = users.FindOne(ctx, nil)
+		require.Equal(ti, sql.ErrNoRows, err)
+	})
+
+	t.Run("Paginate", func(ti *testing.T) {
+		r.Columns = []string{"ID", "Name"}
+		r.Rows = [][]driver.Value{{int64(1), "a"}, {int64(2), "b"}, {int64(3), "c"}}
+		page, cursor, err := users.Paginate(ctx, actions.Paginate().Limit(2), nil)
+		require.NoError(ti, err)
+		require.Equal(ti, []typedUser{{ID: 1, Name: "a"}, {ID: 2, Name: "b"}}, page)
+		require.Equal(ti, int64(3), cursor)
+
+		// the last page
+		r.Stmts = nil
+		r.Rows = [][]driver.Value{{int64(3), "c"}}
+		page, cursor, err = users.Paginate(ctx, actions.Paginate().Limit(2), nil)
+		require.NoError(ti, err)
+		require.Equal(ti, []typedUser{{ID: 3, Name: "c"}}, page)
+		require.Nil(ti, cursor)
+		require.Equal(ti, []string{"SELECT * FROM `db`.`users` ORDER BY `ID` LIMIT 3;"}, r.Stmts)
+
+		// the cursor is located by the field with `primary_key` tag
+		r.Stmts = nil
+		page, cursor, err = users.Paginate(ctx, actions.Paginate().Limit(2), int64(3))
+		require.NoError(ti, err)
+		require.Equal(ti, []typedUser{{ID: 3, Name: "c"}}, page)
+		require.Nil(ti, cursor)
+		require.Equal(ti, 2, len(r.Stmts))
+		require.Equal(ti, "SELECT `ID` FROM `db`.`users` WHERE `ID` = ? LIMIT 1;", r.Stmts[0])
+	})
+}
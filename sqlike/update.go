@@ -23,16 +23,17 @@ func (tb *Table) UpdateOne(ctx context.Context, act actions.UpdateOneStatement,
 	}
 
 	x.Limit(1)
-	return update(
+	affected, err := update(
 		ctx,
 		tb.dbName,
 		tb.name,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
 		tb.logger,
 		&x.UpdateActions,
 		&opt.UpdateOptions,
 	)
+	return affected, tb.dialect.MapError(err)
 }
 
 // Update :
@@ -45,16 +46,17 @@ func (tb *Table) Update(ctx context.Context, act actions.UpdateStatement, opts .
 	if len(opts) > 0 && opts[0] != nil {
 		opt = opts[0]
 	}
-	return update(
+	affected, err := update(
 		ctx,
 		tb.dbName,
 		tb.name,
-		tb.driver,
+		tb.executor(ctx),
 		tb.dialect,
 		tb.logger,
 		x,
 		opt,
 	)
+	return affected, tb.dialect.MapError(err)
 }
 
 func update(ctx context.Context, dbName, tbName string, driver sqldriver.Driver, dialect sqldialect.Dialect, logger logs.Logger, act *actions.UpdateActions, opt *options.UpdateOptions) (int64, error) {